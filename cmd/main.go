@@ -1,13 +1,21 @@
 package main
 
 import (
+	"context"
+	"io"
 	"log"
 	_ "main/docs"
+	"main/internal/catalog"
 	"main/internal/config"
+	"main/internal/handlers"
 	"main/internal/repositories"
 	"main/internal/routes"
+	"main/internal/server"
 	"main/internal/store"
 	"main/pkg/middleware"
+	"os"
+	"os/signal"
+	"syscall"
 
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -19,18 +27,66 @@ func main() {
 		log.Fatalf("Can`t read congif from ENV: %v", err)
 	}
 
-	newStore, err := store.NewStore(*cfg)
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(*cfg, os.Args[2:]); err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		return
+	}
+
+	var catRepo repositories.CatStore
+	var missionRepo repositories.MissionStore
+	var breedRepo repositories.BreedStore
+	var auditRepo repositories.AuditStore
+	var pool handlers.PoolStatter
+	var closers []io.Closer
+	switch cfg.Storage {
+	case "postgres":
+		newStore, err := store.NewStore(*cfg)
+		if err != nil {
+			log.Fatalf("Can`t create store: %v", err)
+		}
+		catRepo = repositories.NewCatRepository(*newStore)
+		missionRepo = repositories.NewMissionRepository(*newStore, cfg.Missions)
+		breedRepo = repositories.NewBreedRepository(*newStore)
+		auditRepo = repositories.NewAuditRepository(*newStore)
+		pool = newStore
+		closers = append(closers, newStore)
+	case "memory":
+		memStore := repositories.NewMemoryStore()
+		catRepo = repositories.NewMemoryCatRepository(memStore)
+		missionRepo = repositories.NewMemoryMissionRepository(memStore, cfg.Missions)
+		breedRepo = repositories.NewMemoryBreedRepository(memStore)
+		auditRepo = repositories.NewMemoryAuditRepository(memStore)
+		log.Println("Using in-memory storage, data will not be persisted")
+	default:
+		log.Fatalf("Unknown storage %q, expected \"postgres\" or \"memory\"", cfg.Storage)
+	}
+
+	breeds, err := catalog.NewBreedCatalog(context.Background(), cfg.Breeds, breedRepo)
+	if err != nil {
+		log.Fatalf("Can`t load breed catalog: %v", err)
+	}
+	countries, err := catalog.NewCountryRegistry()
 	if err != nil {
-		log.Fatalf("Can`t create store: %v", err)
+		log.Fatalf("Can`t load country registry: %v", err)
 	}
-	catRepo := repositories.NewCatRepository(*newStore)
-	missionRepo := repositories.NewMissionRepository(*newStore)
 
-	r := routes.SetupRouter(catRepo, missionRepo)
+	r := routes.SetupRouter(catRepo, missionRepo, auditRepo, breeds, countries, pool)
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 	r.Use(middleware.Logger())
 
-	if err := r.Run(":8080"); err != nil {
+	srv, err := server.New(cfg.HTTP, r)
+	if err != nil {
+		log.Fatalf("Can`t create server: %v", err)
+	}
+
+	// The first signal shuts the server down gracefully, a second one kills it
+	signals, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	context.AfterFunc(signals, stop)
+
+	if err := srv.Run(signals, []func(context.Context){breeds.Run}, closers...); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
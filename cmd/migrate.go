@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"main/internal/config"
+	"main/internal/store"
+	"strconv"
+)
+
+// runMigrate handles `main migrate [up | down [steps] | status]`
+func runMigrate(cfg config.Config, args []string) error {
+	cfg.Postgres.AutoMigrate = false
+	newStore, err := store.NewStore(cfg)
+	if err != nil {
+		return err
+	}
+	defer newStore.DB.Close()
+
+	migrator, err := store.NewMigrator(newStore.DB)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	command := "up"
+	if len(args) > 0 {
+		command = args[0]
+	}
+
+	switch command {
+	case "up":
+		return migrator.Up(ctx)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("invalid number of steps %q", args[1])
+			}
+		}
+		return migrator.Down(ctx, steps)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			applied := "pending"
+			if status.AppliedAt != nil {
+				applied = "applied " + status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d_%s\t%s\n", status.Version, status.Name, applied)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown migrate command %q, expected up, down or status", command)
+	}
+}
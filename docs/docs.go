@@ -15,50 +15,148 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/cat": {
+        "/audit": {
             "get": {
-                "description": "Get a list of all cats",
+                "description": "Retrieves a page of audit events ordered by ID, optionally filtered. Every change to a cat, mission or target is recorded with the actor from the X-Actor header, the request ID and the entity before and after the change. When more events follow, the X-Next-Cursor header holds the cursor for the next page and the Link header a link to it.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/problem+json"
                 ],
                 "tags": [
-                    "cats"
+                    "audit"
+                ],
+                "summary": "Get the audit trail",
+                "parameters": [
+                    {
+                        "enum": [
+                            "cat",
+                            "mission",
+                            "target"
+                        ],
+                        "type": "string",
+                        "description": "Only events about cats, missions or targets",
+                        "name": "entity_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only events about the entity with this ID",
+                        "name": "entity_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events caused by the actor",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events at or after this RFC 3339 time",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events before this RFC 3339 time",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Cursor returned in X-Next-Cursor by the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get all cats",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "List of audit events",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.SpyCat"
+                                "$ref": "#/definitions/model.AuditEvent"
+                            }
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Link to the next page"
+                            },
+                            "X-Next-Cursor": {
+                                "type": "integer",
+                                "description": "Cursor of the next page, absent on the last page"
                             }
                         }
                     },
+                    "400": {
+                        "description": "Invalid query parameter",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Failed to retrieve audit events",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
             }
         },
-        "/cat/{id}": {
+        "/breeds": {
             "get": {
-                "description": "Get a single spy cat by its ID",
+                "description": "Lists the breeds accepted when creating a cat, optionally filtered by a name prefix. The prefix is matched case-insensitively against breed names and alternative names.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "cats"
+                    "breeds"
                 ],
-                "summary": "Get a single spy cat by ID",
+                "summary": "List cat breeds",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Cat ID",
+                        "type": "string",
+                        "description": "Breed name prefix",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of breeds",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Breed"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/breeds/{id}": {
+            "get": {
+                "description": "Retrieves a single breed by its TheCatAPI ID",
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "breeds"
+                ],
+                "summary": "Get a breed by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Breed ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -66,47 +164,35 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/model.SpyCat"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid cat ID",
+                        "description": "Breed details",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Breed"
                         }
                     },
                     "404": {
-                        "description": "Cat not found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "500": {
-                        "description": "Internal server error",
+                        "description": "Breed not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a spy cat by its ID",
+            }
+        },
+        "/breeds/{id}/cats": {
+            "get": {
+                "description": "Retrieves all spy cats of the breed with the given TheCatAPI ID",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/problem+json"
                 ],
                 "tags": [
-                    "cats"
+                    "breeds"
                 ],
-                "summary": "Delete a spy cat",
+                "summary": "List spy cats of a breed",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Cat ID",
+                        "type": "string",
+                        "description": "Breed ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -114,90 +200,140 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Cat deleted successfully",
+                        "description": "List of cats",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.SpyCat"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Invalid cat ID",
+                    "404": {
+                        "description": "Breed not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "500": {
-                        "description": "Failed to delete cat",
+                        "description": "Failed to retrieve cats",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
             }
         },
-        "/cat/{id}/salary": {
-            "put": {
-                "description": "Update the salary of a spy cat by its ID",
+        "/cat": {
+            "get": {
+                "description": "Get a page of cats, optionally filtered and sorted. The total number of matching cats is returned in the X-Total-Count header and links to neighbouring pages in the Link header.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/problem+json"
                 ],
                 "tags": [
                     "cats"
                 ],
-                "summary": "Update cat's salary",
+                "summary": "Get all cats",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Case-insensitive substring of the cat name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Breed name, ID or alternative name",
+                        "name": "breed",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "Cat ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Minimum years of experience",
+                        "name": "min_experience",
+                        "in": "query"
                     },
                     {
-                        "description": "Salary data",
-                        "name": "salary",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.SalaryUpdate"
-                        }
+                        "type": "integer",
+                        "description": "Maximum years of experience",
+                        "name": "max_experience",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum salary",
+                        "name": "min_salary",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum salary",
+                        "name": "max_salary",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated fields (id, name, experience_in_years, breed, salary); prefix with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of cats to skip",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Salary updated successfully",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.SpyCat"
+                            }
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Links to the next and previous pages"
+                            },
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Total number of matching cats"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid cat ID or request body",
+                        "description": "Invalid query parameter",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "500": {
-                        "description": "Failed to update salary",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
-            }
-        },
-        "/cats": {
+            },
             "post": {
-                "description": "Create a new cat in the system",
+                "description": "Create a new cat. The breed is matched case-insensitively against the breed catalog, including alternative names, and stored under its canonical name.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/problem+json"
                 ],
                 "tags": [
                     "cats"
@@ -205,12 +341,12 @@ const docTemplate = `{
                 "summary": "Create a new cat",
                 "parameters": [
                     {
-                        "description": "Cat to add",
+                        "description": "Cat data",
                         "name": "cat",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.SpyCat"
+                            "$ref": "#/definitions/model.CreateCatRequest"
                         }
                     }
                 ],
@@ -222,253 +358,416 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Malformed request body",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid fields or unknown breed",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Failed to create cat",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
             }
         },
-        "/mission": {
+        "/cat/{id}": {
             "get": {
-                "description": "Retrieves a list of all missions",
+                "description": "Get a single spy cat by its ID",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/problem+json"
                 ],
                 "tags": [
-                    "missions"
+                    "cats"
+                ],
+                "summary": "Get a single spy cat by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cat ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached copy of the cat",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
                 ],
-                "summary": "Get all missions",
                 "responses": {
                     "200": {
-                        "description": "List of missions",
+                        "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Mission"
+                            "$ref": "#/definitions/model.SpyCat"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the cat"
                             }
                         }
                     },
+                    "304": {
+                        "description": "Cached copy is up to date"
+                    },
+                    "400": {
+                        "description": "Invalid cat ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Cat not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
                     "500": {
-                        "description": "Failed to retrieve missions",
+                        "description": "Failed to retrieve cat",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
             },
-            "post": {
-                "description": "Create a new mission and its associated targets",
+            "put": {
+                "description": "Replace all fields of a spy cat. A changed breed is validated against the breed catalog and stored under its canonical name.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/problem+json"
                 ],
                 "tags": [
-                    "missions"
+                    "cats"
                 ],
-                "summary": "Create a mission with targets",
+                "summary": "Replace a spy cat",
                 "parameters": [
                     {
-                        "description": "Mission details with targets",
-                        "name": "mission",
+                        "type": "integer",
+                        "description": "Cat ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Cat data",
+                        "name": "cat",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.Mission"
+                            "$ref": "#/definitions/model.CreateCatRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the cat; the request fails if the cat has changed since",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Mission created successfully",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Mission"
+                            "$ref": "#/definitions/model.SpyCat"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the cat"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid cat ID or malformed request body",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Cat not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Cat has been modified",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid fields or unknown breed",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "500": {
-                        "description": "Failed to create mission",
+                        "description": "Failed to update cat",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
-            }
-        },
-        "/mission/targets/{target_id}": {
+            },
             "delete": {
-                "description": "Deletes a specified target from a mission by its ID.",
+                "description": "Delete a spy cat by its ID",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/problem+json"
                 ],
                 "tags": [
-                    "missions"
+                    "cats"
                 ],
-                "summary": "Delete a target from a mission",
+                "summary": "Delete a spy cat",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Target ID",
-                        "name": "target_id",
+                        "description": "Cat ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the cat; the request fails if the cat has changed since",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Target deleted successfully",
+                        "description": "Cat deleted successfully",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Invalid target ID",
+                        "description": "Invalid cat ID",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Cat not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Cat has an open mission",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Cat has been modified",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "500": {
-                        "description": "Failed to delete target",
+                        "description": "Failed to delete cat",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
-            }
-        },
-        "/mission/targets/{target_id}/complete": {
-            "put": {
-                "description": "Marks a specified mission target as complete if found.",
-                "produces": [
+            },
+            "patch": {
+                "description": "Update a spy cat with a JSON Merge Patch (RFC 7396). Only the fields present in the patch are changed; the patched cat must satisfy the same rules as a new one. A changed breed is validated against the breed catalog.",
+                "consumes": [
+                    "application/merge-patch+json",
                     "application/json"
                 ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
                 "tags": [
-                    "missions"
+                    "cats"
                 ],
-                "summary": "Mark a mission target as complete",
+                "summary": "Partially update a spy cat",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Target ID",
-                        "name": "target_id",
+                        "description": "Cat ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateCatRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the cat; the request fails if the cat has changed since",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Target marked as complete",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.SpyCat"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the cat"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid target ID",
+                        "description": "Invalid cat ID or malformed patch",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "404": {
-                        "description": "Target not found",
+                        "description": "Cat not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Cat has been modified, possibly while the patch was applied",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported content type",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid fields or unknown breed",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update cat",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
             }
         },
-        "/mission/targets/{target_id}/notes": {
+        "/cat/{id}/salary": {
             "put": {
-                "description": "Update the notes for a mission target if it has not been marked as complete.",
+                "description": "Update the salary of a spy cat by its ID. The change is recorded in the salary history, approved by the actor from the X-Actor header, and takes effect on effective_from, today by default. It may be backdated, but neither into the future nor before the previous change.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/problem+json"
                 ],
                 "tags": [
-                    "missions"
+                    "cats"
                 ],
-                "summary": "Update notes for a target (only if not completed)",
+                "summary": "Update cat's salary",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Target ID",
-                        "name": "target_id",
+                        "description": "Cat ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated notes",
-                        "name": "notes",
+                        "description": "Salary data",
+                        "name": "salary",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.NoteUpdate"
+                            "$ref": "#/definitions/model.SalaryUpdate"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the cat; the request fails if the cat has changed since",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Notes updated successfully",
+                        "description": "Salary updated successfully",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Invalid target ID or request body",
+                        "description": "Invalid cat ID or malformed request body",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Cat not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Cat has been modified",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Salary missing or out of range, or invalid effective date",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "500": {
-                        "description": "Failed to update notes",
+                        "description": "Failed to update salary",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
             }
         },
-        "/mission/{id}": {
+        "/cat/{id}/salary-history": {
             "get": {
-                "description": "Retrieves a mission by its ID",
+                "description": "Retrieves the salary changes of a spy cat in the order they take effect, including the initial salary and, for a deleted cat, the change to zero on deletion",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/problem+json"
                 ],
                 "tags": [
-                    "missions"
+                    "cats"
                 ],
-                "summary": "Get a single mission by ID",
+                "summary": "Get a cat's salary history",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Mission ID",
+                        "description": "Cat ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -476,163 +775,919 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Mission details",
+                        "description": "Salary history",
                         "schema": {
-                            "$ref": "#/definitions/model.Mission"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.SalaryChange"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid mission ID",
+                        "description": "Invalid cat ID",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "404": {
-                        "description": "Mission not found",
+                        "description": "Cat not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve salary history",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a mission, but only if it's not assigned to a cat. Returns an error if the mission is assigned to a cat.",
+            }
+        },
+        "/countries": {
+            "get": {
+                "description": "Lists the ISO 3166-1 countries accepted as target countries, optionally filtered by a prefix of their code, name or alternative name. Targets store countries as alpha-2 codes.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "missions"
+                    "countries"
                 ],
-                "summary": "Delete a mission (only if it’s not assigned to a cat)",
+                "summary": "List countries",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Mission ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "Country code or name prefix",
+                        "name": "search",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Mission deleted successfully",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid mission ID",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "500": {
-                        "description": "Failed to delete mission",
+                        "description": "List of countries",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Country"
+                            }
                         }
                     }
                 }
             }
         },
-        "/mission/{id}/assign-cat": {
-            "post": {
-                "description": "Assigns a specified cat to an existing mission by its ID.",
+        "/mission": {
+            "get": {
+                "description": "Retrieves a page of missions ordered by ID, optionally filtered. When more missions follow, the X-Next-Cursor header holds the cursor for the next page and the Link header a link to it.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/problem+json"
                 ],
                 "tags": [
                     "missions"
                 ],
-                "summary": "Assign a cat to a mission",
+                "summary": "Get all missions",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Mission ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "boolean",
+                        "description": "Only completed or only incomplete missions",
+                        "name": "completed",
+                        "in": "query"
                     },
                     {
-                        "description": "Cat ID",
+                        "type": "integer",
+                        "description": "Only missions assigned to the cat",
                         "name": "cat_id",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "integer"
-                        }
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only missions without an assigned cat",
+                        "name": "unassigned",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only missions with a target in the country, given by ISO 3166 code or name",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only missions with (or without) incomplete targets",
+                        "name": "has_incomplete_targets",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Cursor returned in X-Next-Cursor by the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size (1-100)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Cat assigned to mission",
+                        "description": "List of missions",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Mission"
+                            }
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Link to the next page"
+                            },
+                            "X-Next-Cursor": {
+                                "type": "integer",
+                                "description": "Cursor of the next page, absent on the last page"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or mission ID",
+                        "description": "Invalid query parameter",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "500": {
-                        "description": "Failed to assign cat",
+                        "description": "Failed to retrieve missions",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
-            }
-        },
-        "/mission/{id}/complete": {
-            "put": {
-                "description": "Mark a mission as completed in the system.",
-                "produces": [
+            },
+            "post": {
+                "description": "Create a new mission and its associated targets. A mission has between one and three targets by default. Target countries may be given by name or ISO 3166 alpha-2 or alpha-3 code and are stored as alpha-2 codes.",
+                "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
                 "tags": [
                     "missions"
                 ],
-                "summary": "Mark a mission as complete",
+                "summary": "Create a mission with targets",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Mission ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Mission details with targets",
+                        "name": "mission",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateMissionRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Mission marked as complete",
+                    "201": {
+                        "description": "Mission created successfully",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Mission"
                         }
                     },
                     "400": {
-                        "description": "Invalid mission ID",
+                        "description": "Malformed request body",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
-                    "500": {
-                        "description": "Failed to complete mission",
+                    "404": {
+                        "description": "Cat not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Cat already has an active mission",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid fields or number of targets",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create mission",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/mission/assignments": {
+            "get": {
+                "description": "Lists which cats were assigned to which missions and when, in the order they were assigned. Open assignments have no unassigned_at.",
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "missions"
+                ],
+                "summary": "Get the assignment history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Only assignments of the mission",
+                        "name": "mission_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only assignments of the cat",
+                        "name": "cat_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Assignment history",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.MissionAssignment"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameter",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve assignments",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/mission/targets/{target_id}": {
+            "delete": {
+                "description": "Deletes a specified target from a mission by its ID.",
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "missions"
+                ],
+                "summary": "Delete a target from a mission",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Target ID",
+                        "name": "target_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version of the target as an entity tag, e.g. \\",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Target deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid target ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Target not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Target is completed",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Target has been modified",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Mission would have too few targets",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete target",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/mission/targets/{target_id}/complete": {
+            "put": {
+                "description": "Marks a specified mission target as complete if found. The mission must be assigned or in progress; completing the first target starts an assigned mission and completing the last open target completes the mission, which is reported in mission_completed.",
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "missions"
+                ],
+                "summary": "Mark a mission target as complete",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Target ID",
+                        "name": "target_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version of the target as an entity tag, e.g. \\",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Target marked as complete",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid target ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Target not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Target is already complete or the mission is not assigned or in progress",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Target has been modified",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to complete target",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/mission/targets/{target_id}/notes": {
+            "put": {
+                "description": "Update the notes for a mission target if it has not been marked as complete.",
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "missions"
+                ],
+                "summary": "Update notes for a target (only if not completed)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Target ID",
+                        "name": "target_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated notes",
+                        "name": "notes",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.NoteUpdate"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version of the target as an entity tag, e.g. \\",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Notes updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid target ID or malformed request body",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Target not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Target or mission is completed",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Target has been modified",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Notes missing",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update notes",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/mission/{id}": {
+            "get": {
+                "description": "Retrieves a mission by its ID",
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "missions"
+                ],
+                "summary": "Get a single mission by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Mission ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached copy of the mission",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Mission details",
+                        "schema": {
+                            "$ref": "#/definitions/model.Mission"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the mission"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Cached copy is up to date"
+                    },
+                    "400": {
+                        "description": "Invalid mission ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Mission not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve mission",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a mission, but only if it's not assigned to a cat. Returns an error if the mission is assigned to a cat; unassign the cat first. Completed and aborted missions can be unassigned too, keeping their status, and then deleted.",
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "missions"
+                ],
+                "summary": "Delete a mission (only if it’s not assigned to a cat)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Mission ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the mission; the request fails if the mission has changed since",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Mission deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid mission ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Mission not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Mission is assigned to a cat",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Mission has been modified",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete mission",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/mission/{id}/assign-cat": {
+            "post": {
+                "description": "Assigns a specified cat to an existing draft mission by its ID. A cat can work on only one open mission at a time.",
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "missions"
+                ],
+                "summary": "Assign a cat to a mission",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Mission ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Cat to assign",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AssignCatRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cat assigned to mission",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid mission ID or malformed request body",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Mission or cat not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Mission is already assigned or not a draft, or the cat already has an active mission",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Cat ID missing",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to assign cat",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes the cat from an assigned mission, which returns to draft and can then be deleted or assigned to another cat. A completed or aborted mission keeps its status, so it can be deleted. Missions already in progress are handed over with reassign instead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "missions"
+                ],
+                "summary": "Unassign the cat from a mission",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Mission ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Optional reason",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.UnassignCatRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the mission; the request fails if the mission has changed since",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cat unassigned from mission",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or mission ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Mission not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Mission has no cat or is in progress",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Mission has been modified",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to unassign cat",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/mission/{id}/complete": {
+            "put": {
+                "description": "Mark a mission as completed in the system. Only missions in progress can be completed, and only once all their targets are complete unless completion is forced with a reason.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "missions"
+                ],
+                "summary": "Mark a mission as complete",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Mission ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Force completion of a mission with open targets",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.CompleteMissionRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the mission; the request fails if the mission has changed since",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Mission marked as complete",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid mission ID or malformed request body",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Mission not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Mission cannot be completed in its current status or has open targets",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Mission has been modified",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Forced completion without a reason",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to complete mission",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/mission/{id}/reassign": {
+            "post": {
+                "description": "Replaces the cat of an assigned or in-progress mission without changing its status. The reason is kept in the assignment history.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "missions"
+                ],
+                "summary": "Hand a mission over to another cat",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Mission ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New cat and the reason for the change",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReassignCatRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Mission reassigned",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "400": {
+                        "description": "Invalid mission ID or malformed request body",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Mission or cat not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Mission has no cat, is closed or already assigned to the cat, or the cat already has an active mission",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Cat ID or reason missing",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to reassign mission",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
                     }
                 }
             }
@@ -641,7 +1696,8 @@ const docTemplate = `{
             "post": {
                 "description": "Adds a new target to a specified mission by its ID.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/problem+json"
                 ],
                 "tags": [
                     "missions"
@@ -661,7 +1717,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.Target"
+                            "$ref": "#/definitions/model.CreateTargetRequest"
                         }
                     }
                 ],
@@ -674,17 +1730,170 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid mission ID",
+                        "description": "Invalid mission ID or malformed request body",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Mission not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Mission is completed or aborted",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid fields, or the mission already has the maximum number of targets",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "500": {
                         "description": "Failed to add target",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/mission/{id}/transition": {
+            "post": {
+                "description": "Moves a mission to another status following its lifecycle: draft → assigned → in_progress → completed, and any open mission → aborted. A mission is completed only once all its targets are complete. Cats are assigned through the assign-cat endpoint.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "missions"
+                ],
+                "summary": "Change the status of a mission",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Mission ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Target status and optional reason",
+                        "name": "transition",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.MissionTransitionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Mission after the transition",
+                        "schema": {
+                            "$ref": "#/definitions/model.Mission"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid mission ID or malformed request body",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Mission not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Transition not allowed from the current status, or completion of a mission with open targets",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unknown status",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to change mission status",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/payroll": {
+            "get": {
+                "description": "Computes what each cat was owed for a month from the salary history. Salaries are monthly and paid per day, so a month with salary changes is split into periods, each paid salary * days / days in the month and rounded half up to the cent. Cats owed nothing are left out.",
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "payroll"
+                ],
+                "summary": "Get the payroll of a month",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "2024-05",
+                        "description": "Month as YYYY-MM, the current month by default",
+                        "name": "month",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "What each cat was owed",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.PayrollEntry"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid month",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to compute payroll",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats/db": {
+            "get": {
+                "description": "Reports the open, busy and idle connections of the Postgres connection pool and how long requests waited for one. Only available with Postgres storage.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "monitoring"
+                ],
+                "summary": "Get database pool statistics",
+                "responses": {
+                    "200": {
+                        "description": "Connection pool statistics",
+                        "schema": {
+                            "$ref": "#/definitions/model.PoolStats"
                         }
                     }
                 }
@@ -692,6 +1901,207 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "model.AssignCatRequest": {
+            "type": "object",
+            "required": [
+                "cat_id"
+            ],
+            "properties": {
+                "cat_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AuditEntity": {
+            "type": "string",
+            "enum": [
+                "cat",
+                "mission",
+                "target"
+            ],
+            "x-enum-varnames": [
+                "AuditCat",
+                "AuditMission",
+                "AuditTarget"
+            ]
+        },
+        "model.AuditEvent": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "update_salary"
+                },
+                "actor": {
+                    "type": "string",
+                    "example": "alice"
+                },
+                "after": {
+                    "description": "After is the entity after the change, absent for deletions",
+                    "type": "object"
+                },
+                "before": {
+                    "description": "Before is the entity before the change, absent for creations",
+                    "type": "object"
+                },
+                "entity_id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "entity_type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.AuditEntity"
+                        }
+                    ],
+                    "example": "cat"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "occurred_at": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Breed": {
+            "type": "object",
+            "properties": {
+                "alt_names": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "origin": {
+                    "type": "string"
+                },
+                "temperament": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CompleteMissionRequest": {
+            "type": "object",
+            "properties": {
+                "force": {
+                    "type": "boolean"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Country": {
+            "type": "object",
+            "properties": {
+                "alpha2": {
+                    "type": "string",
+                    "example": "UA"
+                },
+                "alpha3": {
+                    "type": "string",
+                    "example": "UKR"
+                },
+                "alt_names": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Ukraine"
+                }
+            }
+        },
+        "model.CreateCatRequest": {
+            "type": "object",
+            "required": [
+                "breed",
+                "experience_in_years",
+                "name",
+                "salary"
+            ],
+            "properties": {
+                "breed": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "experience_in_years": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "salary": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 2700.1
+                }
+            }
+        },
+        "model.CreateMissionRequest": {
+            "type": "object",
+            "properties": {
+                "cat_id": {
+                    "description": "CatID optionally assigns a cat right away",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "targets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CreateTargetRequest"
+                    }
+                }
+            }
+        },
+        "model.CreateTargetRequest": {
+            "type": "object",
+            "required": [
+                "country",
+                "name"
+            ],
+            "properties": {
+                "country": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "notes": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "limit"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "must be between 1 and 100"
+                }
+            }
+        },
         "model.Mission": {
             "type": "object",
             "properties": {
@@ -699,32 +2109,300 @@ const docTemplate = `{
                     "type": "integer"
                 },
                 "completed": {
+                    "description": "Completed mirrors Status == \"completed\" for clients of the earlier API",
                     "type": "boolean"
                 },
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.MissionTransition"
+                    }
+                },
                 "id": {
                     "type": "integer"
                 },
+                "status": {
+                    "$ref": "#/definitions/model.MissionStatus"
+                },
                 "targets": {
                     "type": "array",
                     "items": {
                         "$ref": "#/definitions/model.Target"
                     }
+                },
+                "version": {
+                    "description": "Version is incremented on every change to the mission or its targets and sent as its ETag",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.MissionAssignment": {
+            "type": "object",
+            "properties": {
+                "assigned_at": {
+                    "type": "string"
+                },
+                "cat_id": {
+                    "type": "integer"
+                },
+                "mission_id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "description": "Reason explains why the cat was unassigned or replaced",
+                    "type": "string"
+                },
+                "unassigned_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.MissionStatus": {
+            "type": "string",
+            "enum": [
+                "draft",
+                "assigned",
+                "in_progress",
+                "completed",
+                "aborted"
+            ],
+            "x-enum-varnames": [
+                "MissionDraft",
+                "MissionAssigned",
+                "MissionInProgress",
+                "MissionCompleted",
+                "MissionAborted"
+            ]
+        },
+        "model.MissionTransition": {
+            "type": "object",
+            "properties": {
+                "at": {
+                    "type": "string"
+                },
+                "from": {
+                    "$ref": "#/definitions/model.MissionStatus"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "to": {
+                    "$ref": "#/definitions/model.MissionStatus"
+                }
+            }
+        },
+        "model.MissionTransitionRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/model.MissionStatus"
                 }
             }
         },
         "model.NoteUpdate": {
             "type": "object",
+            "required": [
+                "notes"
+            ],
             "properties": {
                 "notes": {
                     "type": "string"
                 }
             }
         },
+        "model.PayrollEntry": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 1306.5
+                },
+                "cat_id": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "description": "Currency is the ISO 4217 code of the amounts",
+                    "type": "string",
+                    "example": "USD"
+                },
+                "month": {
+                    "type": "string",
+                    "example": "2024-05"
+                },
+                "periods": {
+                    "description": "Periods splits the month by the salary in effect, in order",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PayrollPeriod"
+                    }
+                }
+            }
+        },
+        "model.PayrollPeriod": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 1306.5
+                },
+                "days": {
+                    "type": "integer"
+                },
+                "from": {
+                    "type": "string",
+                    "format": "date",
+                    "example": "2024-05-01"
+                },
+                "salary": {
+                    "type": "number",
+                    "example": 2700.1
+                },
+                "to": {
+                    "type": "string",
+                    "format": "date",
+                    "example": "2024-05-14"
+                }
+            }
+        },
+        "model.PoolStats": {
+            "type": "object",
+            "properties": {
+                "idle": {
+                    "type": "integer"
+                },
+                "in_use": {
+                    "type": "integer"
+                },
+                "max_idle_closed": {
+                    "type": "integer"
+                },
+                "max_idle_time_closed": {
+                    "type": "integer"
+                },
+                "max_lifetime_closed": {
+                    "type": "integer"
+                },
+                "max_open_connections": {
+                    "type": "integer"
+                },
+                "open_connections": {
+                    "type": "integer"
+                },
+                "wait_count": {
+                    "description": "WaitCount and WaitDurationMs add up the waits for a free connection",
+                    "type": "integer"
+                },
+                "wait_duration_ms": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Problem": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Code is the stable, machine-readable identifier of the problem",
+                    "type": "string",
+                    "example": "cat_not_found"
+                },
+                "detail": {
+                    "type": "string",
+                    "example": "cat not found: id 7"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FieldError"
+                    }
+                },
+                "instance": {
+                    "description": "Instance is the path of the request that caused the problem",
+                    "type": "string",
+                    "example": "/cat/7"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Cat not found"
+                },
+                "type": {
+                    "description": "Type is a URI identifying the kind of problem",
+                    "type": "string",
+                    "example": "urn:spy-cats:problem:cat_not_found"
+                }
+            }
+        },
+        "model.ReassignCatRequest": {
+            "type": "object",
+            "required": [
+                "cat_id",
+                "reason"
+            ],
+            "properties": {
+                "cat_id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.SalaryChange": {
+            "type": "object",
+            "properties": {
+                "approved_by": {
+                    "description": "ApprovedBy is the actor who made the change",
+                    "type": "string"
+                },
+                "cat_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "effective_from": {
+                    "type": "string",
+                    "format": "date",
+                    "example": "2024-05-01"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "salary": {
+                    "type": "number",
+                    "example": 2700.1
+                }
+            }
+        },
         "model.SalaryUpdate": {
             "type": "object",
+            "required": [
+                "salary"
+            ],
             "properties": {
+                "effective_from": {
+                    "description": "EffectiveFrom is the first day paid at the new salary, today by default.\nIt may be backdated, but neither into the future nor before the previous change.",
+                    "type": "string",
+                    "format": "date",
+                    "example": "2024-05-01"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
                 "salary": {
-                    "type": "number"
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 2700.1
                 }
             }
         },
@@ -744,7 +2422,12 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "salary": {
-                    "type": "number"
+                    "type": "number",
+                    "example": 2700.1
+                },
+                "version": {
+                    "description": "Version is incremented on every change and sent as the ETag of the cat",
+                    "type": "integer"
                 }
             }
         },
@@ -765,6 +2448,18 @@ const docTemplate = `{
                 },
                 "notes": {
                     "type": "string"
+                },
+                "version": {
+                    "description": "Version is incremented on every change and is matched by If-Match on target requests",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.UnassignCatRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
                 }
             }
         }
@@ -0,0 +1,42 @@
+// Package apperr defines the categories of errors caused by a request rather
+// than by a failing dependency, shared by the domain packages and the handlers.
+package apperr
+
+import "errors"
+
+// Error categories. Specific errors wrap one of them, so callers can tell
+// them apart with errors.Is.
+var (
+	ErrNotFound       = errors.New("not found")
+	ErrConflict       = errors.New("conflict")
+	ErrValidation     = errors.New("validation failed")
+	ErrForbiddenState = errors.New("not allowed in the current state")
+	// ErrPreconditionFailed means the resource changed since the client last read it
+	ErrPreconditionFailed = errors.New("precondition failed")
+)
+
+// domainError is a specific error belonging to one of the error categories
+type domainError struct {
+	kind error
+	code string
+	msg  string
+}
+
+// New creates an error with its own message that matches kind with errors.Is.
+// The code identifies the error to API clients and must not change once published.
+func New(kind error, code, msg string) error {
+	return &domainError{kind: kind, code: code, msg: msg}
+}
+
+func (e *domainError) Error() string {
+	return e.msg
+}
+
+func (e *domainError) Unwrap() error {
+	return e.kind
+}
+
+// Code returns the stable identifier of the error
+func (e *domainError) Code() string {
+	return e.code
+}
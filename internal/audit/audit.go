@@ -0,0 +1,30 @@
+// Package audit carries who made a request down to the repositories, which
+// record it with every change they make.
+package audit
+
+import "context"
+
+// UnknownActor is recorded for requests that do not name their actor
+const UnknownActor = "anonymous"
+
+// Source identifies who made a change and in which request
+type Source struct {
+	Actor     string
+	RequestID string
+}
+
+type sourceKey struct{}
+
+// NewContext returns a copy of ctx carrying the source
+func NewContext(ctx context.Context, source Source) context.Context {
+	return context.WithValue(ctx, sourceKey{}, source)
+}
+
+// FromContext returns the source carried by ctx. The actor defaults to UnknownActor.
+func FromContext(ctx context.Context) Source {
+	source, _ := ctx.Value(sourceKey{}).(Source)
+	if source.Actor == "" {
+		source.Actor = UnknownActor
+	}
+	return source
+}
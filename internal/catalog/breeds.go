@@ -0,0 +1,316 @@
+package catalog
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"main/internal/apperr"
+	"main/internal/config"
+	"main/internal/model"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// breedSnapshot is a copy of TheCatAPI breed list bundled with the binary,
+// so breeds can be validated before the first successful sync
+//
+//go:embed breeds_snapshot.json
+var breedSnapshot []byte
+
+// maxBreedListSize limits how much of a breed source response is read
+const maxBreedListSize = 5 << 20
+
+var ErrInvalidBreed = apperr.New(apperr.ErrValidation, "invalid_breed", "invalid breed")
+
+// BreedStore keeps the last fetched breed list across restarts
+type BreedStore interface {
+	LoadBreeds(ctx context.Context) ([]model.Breed, model.BreedSync, error)
+	SaveBreeds(ctx context.Context, breeds []model.Breed, sync model.BreedSync) error
+	SaveBreedSync(ctx context.Context, sync model.BreedSync) error
+}
+
+// apiBreed is the breed format used by TheCatAPI and the bundled snapshot
+type apiBreed struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Origin      string `json:"origin"`
+	Temperament string `json:"temperament"`
+	AltNames    string `json:"alt_names"`
+}
+
+// BreedCatalog validates cat breeds against a locally held breed list.
+// The list starts from the bundled snapshot, is replaced by the last list
+// persisted in the store and is refreshed from the configured source by Run.
+type BreedCatalog struct {
+	sourceURL       string
+	apiKey          string
+	refreshInterval time.Duration
+	retryInterval   time.Duration
+	client          *http.Client
+	store           BreedStore
+
+	mu     sync.RWMutex
+	breeds []model.Breed
+	// index maps normalized IDs, names and alternative names to positions in breeds
+	index map[string]int
+	sync  model.BreedSync
+}
+
+// NewBreedCatalog creates a catalog from the bundled snapshot and the persisted breed list.
+// The store may be nil, in which case fetched breeds are only kept in memory.
+func NewBreedCatalog(ctx context.Context, cfg config.Breeds, store BreedStore) (*BreedCatalog, error) {
+	c := &BreedCatalog{
+		sourceURL:       cfg.SourceURL,
+		apiKey:          cfg.APIKey,
+		refreshInterval: cfg.RefreshInterval,
+		retryInterval:   cfg.RetryInterval,
+		client:          &http.Client{Timeout: cfg.RequestTimeout},
+		store:           store,
+	}
+
+	breeds, err := parseBreeds(breedSnapshot)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load bundled breeds: %v", err)
+	}
+	c.set(breeds, model.BreedSync{})
+
+	if store != nil {
+		persisted, sync, err := store.LoadBreeds(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if len(persisted) > 0 {
+			c.set(persisted, sync)
+		}
+	}
+
+	return c, nil
+}
+
+// Breeds returns all known breeds ordered by name
+func (c *BreedCatalog) Breeds() []model.Breed {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return append([]model.Breed(nil), c.breeds...)
+}
+
+// Search returns the breeds whose name or alternative name starts with the prefix, ignoring case
+func (c *BreedCatalog) Search(prefix string) []model.Breed {
+	prefix = normalizeName(prefix)
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var result []model.Breed
+	for _, breed := range c.breeds {
+		if strings.HasPrefix(normalizeName(breed.Name), prefix) {
+			result = append(result, breed)
+			continue
+		}
+		for _, alias := range breed.AltNames {
+			if strings.HasPrefix(normalizeName(alias), prefix) {
+				result = append(result, breed)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// Get returns the breed with the given TheCatAPI ID
+func (c *BreedCatalog) Get(id string) (model.Breed, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, breed := range c.breeds {
+		if strings.EqualFold(breed.ID, id) {
+			return breed, true
+		}
+	}
+	return model.Breed{}, false
+}
+
+// Lookup finds a breed by its ID, name or alternative name, ignoring case and extra spaces
+func (c *BreedCatalog) Lookup(name string) (model.Breed, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	i, ok := c.index[normalizeName(name)]
+	if !ok {
+		return model.Breed{}, false
+	}
+	return c.breeds[i], true
+}
+
+// Validate checks that the breed is known and returns its canonical name
+func (c *BreedCatalog) Validate(name string) (string, error) {
+	breed, ok := c.Lookup(name)
+	if !ok {
+		return "", ErrInvalidBreed
+	}
+	return breed.Name, nil
+}
+
+// Run refreshes the breed list whenever it is older than the refresh interval
+// until the context is cancelled. It does nothing when no source URL is configured.
+func (c *BreedCatalog) Run(ctx context.Context) {
+	if c.sourceURL == "" {
+		return
+	}
+
+	c.mu.RLock()
+	wait := time.Until(c.sync.FetchedAt.Add(c.refreshInterval))
+	c.mu.RUnlock()
+
+	for {
+		if wait < 0 {
+			wait = 0
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if err := c.Refresh(ctx); err != nil {
+			log.Printf("Breed catalog refresh failed: %v", err)
+			wait = c.retryInterval
+		} else {
+			wait = c.refreshInterval
+		}
+	}
+}
+
+// Refresh fetches the breed list from the source, sending the last ETag so
+// an unchanged list is not downloaded again
+func (c *BreedCatalog) Refresh(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.sourceURL, nil)
+	if err != nil {
+		return err
+	}
+
+	c.mu.RLock()
+	etag := c.sync.ETag
+	c.mu.RUnlock()
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if c.apiKey != "" {
+		req.Header.Set("x-api-key", c.apiKey)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		sync := model.BreedSync{ETag: etag, FetchedAt: time.Now()}
+		c.mu.Lock()
+		c.sync = sync
+		c.mu.Unlock()
+		if c.store != nil {
+			return c.store.SaveBreedSync(ctx, sync)
+		}
+		return nil
+	case http.StatusOK:
+	default:
+		return fmt.Errorf("breed source responded with status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBreedListSize))
+	if err != nil {
+		return err
+	}
+	breeds, err := parseBreeds(body)
+	if err != nil {
+		return err
+	}
+	if len(breeds) == 0 {
+		return errors.New("breed source returned an empty list")
+	}
+
+	sync := model.BreedSync{ETag: resp.Header.Get("ETag"), FetchedAt: time.Now()}
+	if c.store != nil {
+		if err := c.store.SaveBreeds(ctx, breeds, sync); err != nil {
+			// Keep the fresh list but forget the ETag, so the next refresh
+			// downloads the list again and retries persisting it
+			c.set(breeds, model.BreedSync{FetchedAt: sync.FetchedAt})
+			return err
+		}
+	}
+	c.set(breeds, sync)
+	return nil
+}
+
+// set replaces the breed list and rebuilds the lookup index
+func (c *BreedCatalog) set(breeds []model.Breed, sync model.BreedSync) {
+	sorted := append([]model.Breed(nil), breeds...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	index := make(map[string]int, len(sorted)*2)
+	// IDs and names take precedence over alternative names shared by several breeds
+	for i, breed := range sorted {
+		index[normalizeName(breed.ID)] = i
+		index[normalizeName(breed.Name)] = i
+	}
+	for i, breed := range sorted {
+		for _, alias := range breed.AltNames {
+			key := normalizeName(alias)
+			if _, exists := index[key]; !exists {
+				index[key] = i
+			}
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.breeds = sorted
+	c.index = index
+	c.sync = sync
+}
+
+// parseBreeds decodes a breed list in TheCatAPI format
+func parseBreeds(data []byte) ([]model.Breed, error) {
+	var raw []apiBreed
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("unable to decode breeds: %v", err)
+	}
+
+	breeds := make([]model.Breed, 0, len(raw))
+	for _, b := range raw {
+		if b.ID == "" || b.Name == "" {
+			continue
+		}
+		breed := model.Breed{
+			ID:          b.ID,
+			Name:        b.Name,
+			Origin:      b.Origin,
+			Temperament: b.Temperament,
+			AltNames:    []string{},
+		}
+		for _, alias := range strings.Split(b.AltNames, ",") {
+			if alias = strings.TrimSpace(alias); alias != "" {
+				breed.AltNames = append(breed.AltNames, alias)
+			}
+		}
+		breeds = append(breeds, breed)
+	}
+	return breeds, nil
+}
+
+func normalizeName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
@@ -0,0 +1,237 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"main/internal/catalog/catalogtest"
+	"main/internal/config"
+	"main/internal/model"
+	"main/internal/repositories"
+	"net/http"
+	"testing"
+	"time"
+)
+
+var testBreeds = []model.Breed{
+	{ID: "mcoo", Name: "Maine Coon", Origin: "United States", AltNames: []string{"Coon Cat", "Maine Cat"}},
+	{ID: "siam", Name: "Siamese", Origin: "Thailand"},
+	{ID: "zzzz", Name: "Zebra Cat", Origin: "Nowhere", AltNames: []string{"Stripy"}},
+}
+
+func newTestCatalog(t *testing.T, source *catalogtest.Server, refresh, retry time.Duration) (*BreedCatalog, BreedStore) {
+	t.Helper()
+	store := repositories.NewMemoryBreedRepository(repositories.NewMemoryStore())
+	cfg := config.Breeds{
+		SourceURL:       source.URL,
+		RefreshInterval: refresh,
+		RetryInterval:   retry,
+		RequestTimeout:  time.Second,
+	}
+	c, err := NewBreedCatalog(context.Background(), cfg, store)
+	if err != nil {
+		t.Fatalf("NewBreedCatalog: %v", err)
+	}
+	return c, store
+}
+
+// waitFor polls cond until it holds or the deadline passes
+func waitFor(t *testing.T, timeout time.Duration, cond func() bool) bool {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return true
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return cond()
+}
+
+func TestRefreshReplacesBreedsFromSource(t *testing.T) {
+	source := catalogtest.NewServer(testBreeds)
+	defer source.Close()
+	c, store := newTestCatalog(t, source, time.Hour, time.Hour)
+
+	if _, ok := c.Lookup("Zebra Cat"); ok {
+		t.Fatal("breed from the source is known before the first refresh")
+	}
+	if err := c.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+
+	if got := len(c.Breeds()); got != len(testBreeds) {
+		t.Fatalf("got %d breeds, want %d", got, len(testBreeds))
+	}
+	if _, ok := c.Lookup("Zebra Cat"); !ok {
+		t.Fatal("breed from the source is not known after refresh")
+	}
+
+	persisted, sync, err := store.LoadBreeds(context.Background())
+	if err != nil {
+		t.Fatalf("LoadBreeds: %v", err)
+	}
+	if len(persisted) != len(testBreeds) || sync.ETag == "" || sync.FetchedAt.IsZero() {
+		t.Fatalf("persisted %d breeds with sync %+v", len(persisted), sync)
+	}
+}
+
+func TestRefreshUsesETag(t *testing.T) {
+	source := catalogtest.NewServer(testBreeds)
+	defer source.Close()
+	c, store := newTestCatalog(t, source, time.Hour, time.Hour)
+
+	if err := c.Refresh(context.Background()); err != nil {
+		t.Fatalf("first Refresh: %v", err)
+	}
+	_, first, _ := store.LoadBreeds(context.Background())
+
+	if err := c.Refresh(context.Background()); err != nil {
+		t.Fatalf("second Refresh: %v", err)
+	}
+	if got := source.NotModified(); got != 1 {
+		t.Fatalf("source answered %d requests with 304, want 1", got)
+	}
+	if got := len(c.Breeds()); got != len(testBreeds) {
+		t.Fatalf("got %d breeds after 304, want %d", got, len(testBreeds))
+	}
+	_, second, _ := store.LoadBreeds(context.Background())
+	if second.ETag != first.ETag || !second.FetchedAt.After(first.FetchedAt) {
+		t.Fatalf("sync after 304 is %+v, want ETag %q fetched after %s", second, first.ETag, first.FetchedAt)
+	}
+
+	source.SetBreeds(testBreeds[:1])
+	if err := c.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh after change: %v", err)
+	}
+	if got := len(c.Breeds()); got != 1 {
+		t.Fatalf("got %d breeds after the source changed, want 1", got)
+	}
+}
+
+func TestRefreshFailureKeepsLastGoodList(t *testing.T) {
+	source := catalogtest.NewServer(testBreeds)
+	defer source.Close()
+	c, _ := newTestCatalog(t, source, time.Hour, time.Hour)
+
+	if err := c.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+
+	source.FailWith(http.StatusServiceUnavailable)
+	if err := c.Refresh(context.Background()); err == nil {
+		t.Fatal("Refresh succeeded while the source is failing")
+	}
+	if _, ok := c.Lookup("Zebra Cat"); !ok {
+		t.Fatal("last good list was dropped after a failed refresh")
+	}
+
+	source.FailWith(0)
+	source.SetBreeds(nil)
+	if err := c.Refresh(context.Background()); err == nil {
+		t.Fatal("Refresh accepted an empty breed list")
+	}
+	if got := len(c.Breeds()); got != len(testBreeds) {
+		t.Fatalf("got %d breeds after an empty response, want %d", got, len(testBreeds))
+	}
+}
+
+func TestRunWaitsForRefreshInterval(t *testing.T) {
+	source := catalogtest.NewServer(testBreeds)
+	defer source.Close()
+	c, _ := newTestCatalog(t, source, time.Hour, time.Hour)
+	if err := c.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	c.Run(ctx)
+
+	if got := source.Requests(); got != 1 {
+		t.Fatalf("source got %d requests while the list was fresh, want 1", got)
+	}
+}
+
+func TestRunRefreshesAfterInterval(t *testing.T) {
+	source := catalogtest.NewServer(testBreeds)
+	defer source.Close()
+	c, _ := newTestCatalog(t, source, 20*time.Millisecond, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.Run(ctx)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	if !waitFor(t, 2*time.Second, func() bool { return source.Requests() >= 3 }) {
+		t.Fatalf("source got %d requests, want a refresh every interval", source.Requests())
+	}
+	if source.NotModified() == 0 {
+		t.Fatal("periodic refreshes downloaded the unchanged list again")
+	}
+}
+
+func TestRunRetriesFailedRefresh(t *testing.T) {
+	source := catalogtest.NewServer(testBreeds)
+	defer source.Close()
+	source.FailWith(http.StatusInternalServerError)
+	c, _ := newTestCatalog(t, source, time.Hour, 10*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.Run(ctx)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	if !waitFor(t, 2*time.Second, func() bool { return source.Requests() >= 3 }) {
+		t.Fatalf("source got %d requests, want retries every retry interval", source.Requests())
+	}
+
+	source.FailWith(0)
+	if !waitFor(t, 2*time.Second, func() bool { _, ok := c.Lookup("Zebra Cat"); return ok }) {
+		t.Fatal("catalog did not recover once the source came back")
+	}
+	settled := source.Requests()
+	time.Sleep(50 * time.Millisecond)
+	if got := source.Requests(); got != settled {
+		t.Fatalf("source got %d more requests after a successful refresh, want none before the refresh interval", got-settled)
+	}
+}
+
+func TestLookupIgnoresCaseSpacesAndAliases(t *testing.T) {
+	source := catalogtest.NewServer(testBreeds)
+	defer source.Close()
+	c, _ := newTestCatalog(t, source, time.Hour, time.Hour)
+	if err := c.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+
+	for _, name := range []string{"Maine Coon", "maine coon", "  MAINE   coon ", "MCOO", "coon cat", "Maine Cat"} {
+		got, err := c.Validate(name)
+		if err != nil {
+			t.Errorf("Validate(%q): %v", name, err)
+			continue
+		}
+		if got != "Maine Coon" {
+			t.Errorf("Validate(%q) = %q, want %q", name, got, "Maine Coon")
+		}
+	}
+
+	if _, err := c.Validate("Dragon"); !errors.Is(err, ErrInvalidBreed) {
+		t.Fatalf("Validate of an unknown breed returned %v, want ErrInvalidBreed", err)
+	}
+
+	if got := c.Search("str"); len(got) != 1 || got[0].ID != "zzzz" {
+		t.Fatalf("Search by alias prefix returned %+v", got)
+	}
+}
@@ -0,0 +1,103 @@
+// Package catalogtest provides a local stand-in for the TheCatAPI breeds endpoint.
+package catalogtest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"main/internal/model"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+// Server serves a breed list in TheCatAPI format and honours If-None-Match
+type Server struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	body        []byte
+	etag        string
+	status      int
+	requests    int
+	notModified int
+}
+
+// NewServer starts a server returning the given breeds. Close it when done.
+func NewServer(breeds []model.Breed) *Server {
+	s := &Server{}
+	s.SetBreeds(breeds)
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// SetBreeds replaces the served breed list, which also changes its ETag
+func (s *Server) SetBreeds(breeds []model.Breed) {
+	type apiBreed struct {
+		ID          string `json:"id"`
+		Name        string `json:"name"`
+		Origin      string `json:"origin"`
+		Temperament string `json:"temperament"`
+		AltNames    string `json:"alt_names"`
+	}
+	raw := make([]apiBreed, 0, len(breeds))
+	for _, b := range breeds {
+		raw = append(raw, apiBreed{
+			ID:          b.ID,
+			Name:        b.Name,
+			Origin:      b.Origin,
+			Temperament: b.Temperament,
+			AltNames:    strings.Join(b.AltNames, ", "),
+		})
+	}
+	body, _ := json.Marshal(raw)
+	sum := sha256.Sum256(body)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.body = body
+	s.etag = `"` + hex.EncodeToString(sum[:8]) + `"`
+}
+
+// FailWith makes the server answer every request with the given status code.
+// Pass 0 to serve the breed list again.
+func (s *Server) FailWith(status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status = status
+}
+
+// Requests returns the number of requests the server has received
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+// NotModified returns the number of requests answered with 304 Not Modified
+func (s *Server) NotModified() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.notModified
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests++
+
+	if s.status != 0 {
+		w.WriteHeader(s.status)
+		return
+	}
+
+	w.Header().Set("ETag", s.etag)
+	if r.Header.Get("If-None-Match") == s.etag {
+		s.notModified++
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(s.body)
+}
@@ -0,0 +1,90 @@
+package catalog
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"main/internal/apperr"
+	"main/internal/model"
+	"strings"
+)
+
+// countryList is the ISO 3166-1 country list bundled with the binary
+//
+//go:embed countries.json
+var countryList []byte
+
+var ErrInvalidCountry = apperr.New(apperr.ErrValidation, "invalid_country", "invalid country")
+
+// CountryRegistry resolves country names and ISO 3166-1 codes to countries
+type CountryRegistry struct {
+	countries []model.Country
+	// index maps normalized codes, names and alternative names to positions in countries
+	index map[string]int
+}
+
+// NewCountryRegistry creates a registry from the bundled country list
+func NewCountryRegistry() (*CountryRegistry, error) {
+	var countries []model.Country
+	if err := json.Unmarshal(countryList, &countries); err != nil {
+		return nil, fmt.Errorf("unable to load bundled countries: %v", err)
+	}
+
+	index := make(map[string]int, len(countries)*4)
+	// Codes and names take precedence over alternative names
+	for i, country := range countries {
+		index[normalizeName(country.Alpha2)] = i
+		index[normalizeName(country.Alpha3)] = i
+		index[normalizeName(country.Name)] = i
+	}
+	for i, country := range countries {
+		for _, alias := range country.AltNames {
+			key := normalizeName(alias)
+			if _, exists := index[key]; !exists {
+				index[key] = i
+			}
+		}
+	}
+	return &CountryRegistry{countries: countries, index: index}, nil
+}
+
+// Countries returns all countries ordered by name
+func (r *CountryRegistry) Countries() []model.Country {
+	return append([]model.Country(nil), r.countries...)
+}
+
+// Search returns the countries whose code, name or alternative name starts with the prefix, ignoring case
+func (r *CountryRegistry) Search(prefix string) []model.Country {
+	prefix = normalizeName(prefix)
+
+	var result []model.Country
+	for _, country := range r.countries {
+		names := append([]string{country.Alpha2, country.Alpha3, country.Name}, country.AltNames...)
+		for _, name := range names {
+			if strings.HasPrefix(normalizeName(name), prefix) {
+				result = append(result, country)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// Lookup finds a country by its alpha-2 or alpha-3 code, name or alternative name,
+// ignoring case and extra spaces
+func (r *CountryRegistry) Lookup(name string) (model.Country, bool) {
+	i, ok := r.index[normalizeName(name)]
+	if !ok {
+		return model.Country{}, false
+	}
+	return r.countries[i], true
+}
+
+// Normalize returns the alpha-2 code of the country
+func (r *CountryRegistry) Normalize(name string) (string, error) {
+	country, ok := r.Lookup(name)
+	if !ok {
+		return "", ErrInvalidCountry
+	}
+	return country.Alpha2, nil
+}
@@ -1,19 +1,89 @@
 package config
 
 import (
+	"fmt"
+	"time"
+
 	env "github.com/caarlos0/env/v6"
 )
 
 type Config struct {
+	// Storage selects the repository backend: "postgres" or "memory"
+	Storage  string `env:"STORAGE" envDefault:"postgres"`
+	HTTP     HTTP
 	Postgres Postgres
+	Breeds   Breeds
+	Missions Missions
+}
+
+// HTTP configures the API server
+type HTTP struct {
+	Addr              string        `env:"HTTP_ADDR" envDefault:":8080"`
+	ReadTimeout       time.Duration `env:"HTTP_READ_TIMEOUT" envDefault:"15s"`
+	ReadHeaderTimeout time.Duration `env:"HTTP_READ_HEADER_TIMEOUT" envDefault:"5s"`
+	WriteTimeout      time.Duration `env:"HTTP_WRITE_TIMEOUT" envDefault:"30s"`
+	IdleTimeout       time.Duration `env:"HTTP_IDLE_TIMEOUT" envDefault:"2m"`
+	MaxHeaderBytes    int           `env:"HTTP_MAX_HEADER_BYTES" envDefault:"1048576"`
+	// ShutdownTimeout is how long in-flight requests may run after a stop signal
+	ShutdownTimeout time.Duration `env:"HTTP_SHUTDOWN_TIMEOUT" envDefault:"20s"`
+	// TLSCertFile and TLSKeyFile switch the server to HTTPS. With ClientCAFile
+	// clients must also present a certificate signed by one of its CAs.
+	TLSCertFile  string `env:"HTTP_TLS_CERT_FILE"`
+	TLSKeyFile   string `env:"HTTP_TLS_KEY_FILE"`
+	ClientCAFile string `env:"HTTP_TLS_CLIENT_CA_FILE"`
 }
 
 type Postgres struct {
+	// DSN is a complete lib/pq connection string or URL; when set it replaces
+	// the connection settings below, including SSL and the statement timeout
+	DSN      string `env:"PG_DSN"`
 	Host     string `env:"PG_HOST"`
 	Port     int    `env:"PG_PORT"`
 	User     string `env:"PG_USER"`
 	Password string `env:"PG_PASSWORD"`
 	Dbname   string `env:"PG_DB_NAME"`
+	// SSLMode is one of disable, require, verify-ca or verify-full; SSLRootCert
+	// is the CA certificate file the verify modes check the server against
+	SSLMode     string `env:"PG_SSLMODE" envDefault:"disable"`
+	SSLRootCert string `env:"PG_SSLROOTCERT"`
+	// StatementTimeout makes Postgres abort any statement running longer; zero keeps the server default
+	StatementTimeout time.Duration `env:"PG_STATEMENT_TIMEOUT"`
+	// AutoMigrate applies pending schema migrations when the store is created
+	AutoMigrate bool `env:"PG_AUTO_MIGRATE" envDefault:"true"`
+	// QueryTimeout bounds each read and TxTimeout each transaction, from its start
+	// to its commit; zero means no limit beyond the request's own deadline
+	QueryTimeout time.Duration `env:"PG_QUERY_TIMEOUT" envDefault:"5s"`
+	TxTimeout    time.Duration `env:"PG_TX_TIMEOUT" envDefault:"10s"`
+	Pool         Pool
+}
+
+// Pool sizes the connection pool and paces connecting at startup
+type Pool struct {
+	MaxOpenConns    int           `env:"PG_MAX_OPEN_CONNS" envDefault:"25"`
+	MaxIdleConns    int           `env:"PG_MAX_IDLE_CONNS" envDefault:"5"`
+	ConnMaxLifetime time.Duration `env:"PG_CONN_MAX_LIFETIME" envDefault:"30m"`
+	ConnMaxIdleTime time.Duration `env:"PG_CONN_MAX_IDLE_TIME" envDefault:"5m"`
+	// ConnectAttempts is how often to try reaching Postgres at startup, waiting
+	// ConnectBackoff after the first failure and twice as long after each next
+	// one, up to ConnectMaxBackoff
+	ConnectAttempts   int           `env:"PG_CONNECT_ATTEMPTS" envDefault:"10"`
+	ConnectBackoff    time.Duration `env:"PG_CONNECT_BACKOFF" envDefault:"500ms"`
+	ConnectMaxBackoff time.Duration `env:"PG_CONNECT_MAX_BACKOFF" envDefault:"10s"`
+}
+
+type Breeds struct {
+	// SourceURL is the TheCatAPI compatible breed list; leave empty to use only the bundled and stored breeds
+	SourceURL       string        `env:"BREEDS_SOURCE_URL" envDefault:"https://api.thecatapi.com/v1/breeds"`
+	APIKey          string        `env:"BREEDS_API_KEY"`
+	RefreshInterval time.Duration `env:"BREEDS_REFRESH_INTERVAL" envDefault:"24h"`
+	RetryInterval   time.Duration `env:"BREEDS_RETRY_INTERVAL" envDefault:"5m"`
+	RequestTimeout  time.Duration `env:"BREEDS_REQUEST_TIMEOUT" envDefault:"10s"`
+}
+
+// Missions holds the agency rules for missions
+type Missions struct {
+	MinTargets int `env:"MISSION_MIN_TARGETS" envDefault:"1"`
+	MaxTargets int `env:"MISSION_MAX_TARGETS" envDefault:"3"`
 }
 
 func NewFromEnv() (*Config, error) {
@@ -21,6 +91,55 @@ func NewFromEnv() (*Config, error) {
 	if err := env.Parse(&config); err != nil {
 		return nil, err
 	}
+	if config.Missions.MinTargets < 0 || config.Missions.MaxTargets < 1 || config.Missions.MinTargets > config.Missions.MaxTargets {
+		return nil, fmt.Errorf("invalid mission target limits: min %d, max %d", config.Missions.MinTargets, config.Missions.MaxTargets)
+	}
+
+	if config.Postgres.QueryTimeout < 0 || config.Postgres.TxTimeout < 0 {
+		return nil, fmt.Errorf("invalid postgres timeouts: query %s, transaction %s", config.Postgres.QueryTimeout, config.Postgres.TxTimeout)
+	}
+	if err := config.HTTP.validate(); err != nil {
+		return nil, err
+	}
+	if err := config.Postgres.validate(); err != nil {
+		return nil, err
+	}
 
 	return &config, nil
 }
+
+func (h HTTP) validate() error {
+	if h.ReadTimeout < 0 || h.ReadHeaderTimeout < 0 || h.WriteTimeout < 0 || h.IdleTimeout < 0 || h.MaxHeaderBytes < 0 || h.ShutdownTimeout < 0 {
+		return fmt.Errorf("invalid http limits: read %s, read header %s, write %s, idle %s, max header %d bytes, shutdown %s",
+			h.ReadTimeout, h.ReadHeaderTimeout, h.WriteTimeout, h.IdleTimeout, h.MaxHeaderBytes, h.ShutdownTimeout)
+	}
+	if (h.TLSCertFile == "") != (h.TLSKeyFile == "") {
+		return fmt.Errorf("http tls needs both a certificate and a key file")
+	}
+	if h.ClientCAFile != "" && h.TLSCertFile == "" {
+		return fmt.Errorf("http client certificates need tls")
+	}
+	return nil
+}
+
+func (p Postgres) validate() error {
+	switch p.SSLMode {
+	// lib/pq does not support allow and prefer
+	case "disable", "require", "verify-ca", "verify-full":
+	default:
+		return fmt.Errorf("invalid postgres sslmode %q", p.SSLMode)
+	}
+	if p.StatementTimeout < 0 {
+		return fmt.Errorf("invalid postgres statement timeout %s", p.StatementTimeout)
+	}
+	pool := p.Pool
+	if pool.MaxOpenConns < 0 || pool.MaxIdleConns < 0 || pool.ConnMaxLifetime < 0 || pool.ConnMaxIdleTime < 0 {
+		return fmt.Errorf("invalid postgres pool: max open %d, max idle %d, lifetime %s, idle time %s",
+			pool.MaxOpenConns, pool.MaxIdleConns, pool.ConnMaxLifetime, pool.ConnMaxIdleTime)
+	}
+	if pool.ConnectAttempts < 1 || pool.ConnectBackoff < 0 || pool.ConnectMaxBackoff < pool.ConnectBackoff {
+		return fmt.Errorf("invalid postgres connect retry: %d attempts, backoff %s up to %s",
+			pool.ConnectAttempts, pool.ConnectBackoff, pool.ConnectMaxBackoff)
+	}
+	return nil
+}
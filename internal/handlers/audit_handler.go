@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"main/internal/audit"
+	"main/internal/model"
+	"main/internal/repositories"
+	"main/pkg/middleware"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// actorHeader names who makes a request, as there is no authentication yet
+	actorHeader    = "X-Actor"
+	maxActorLength = 255
+)
+
+type AuditHandler struct {
+	AuditRepo repositories.AuditStore
+}
+
+func NewAuditHandler(auditRepo repositories.AuditStore) *AuditHandler {
+	return &AuditHandler{AuditRepo: auditRepo}
+}
+
+// AuditSource passes the actor named in the X-Actor header and the request ID
+// to the repositories, which record them with every change
+func AuditSource(c *gin.Context) {
+	actor := strings.TrimSpace(c.GetHeader(actorHeader))
+	if len(actor) > maxActorLength {
+		invalidParam(c, actorHeader, "must be at most 255 characters long")
+		return
+	}
+
+	source := audit.Source{Actor: actor, RequestID: c.GetString(middleware.RequestIDKey)}
+	c.Request = c.Request.WithContext(audit.NewContext(c.Request.Context(), source))
+	c.Next()
+}
+
+// GetAuditEvents godoc
+// @Summary Get the audit trail
+// @Description Retrieves a page of audit events ordered by ID, optionally filtered. Every change to a cat, mission or target is recorded with the actor from the X-Actor header, the request ID and the entity before and after the change. When more events follow, the X-Next-Cursor header holds the cursor for the next page and the Link header a link to it.
+// @Tags audit
+// @Produce json,application/problem+json
+// @Param entity_type query string false "Only events about cats, missions or targets" Enums(cat, mission, target)
+// @Param entity_id query int false "Only events about the entity with this ID"
+// @Param actor query string false "Only events caused by the actor"
+// @Param from query string false "Only events at or after this RFC 3339 time"
+// @Param to query string false "Only events before this RFC 3339 time"
+// @Param cursor query int false "Cursor returned in X-Next-Cursor by the previous page"
+// @Param limit query int false "Page size (1-100)" default(50)
+// @Success 200 {array} model.AuditEvent "List of audit events"
+// @Header 200 {integer} X-Next-Cursor "Cursor of the next page, absent on the last page"
+// @Header 200 {string} Link "Link to the next page"
+// @Failure 400 {object} model.Problem "Invalid query parameter"
+// @Failure 500 {object} model.Problem "Failed to retrieve audit events"
+// @Router /audit [get]
+func (h *AuditHandler) GetAuditEvents(c *gin.Context) {
+	filter, err := parseAuditFilter(c)
+	if err != nil {
+		badQuery(c, err)
+		return
+	}
+
+	events, nextCursor, err := h.AuditRepo.GetEvents(c.Request.Context(), filter)
+	if err != nil {
+		respondError(c, err, "Failed to retrieve audit events")
+		return
+	}
+
+	setCursorLink(c, nextCursor)
+	c.JSON(http.StatusOK, events)
+}
+
+// parseAuditFilter reads the audit listing query parameters
+func parseAuditFilter(c *gin.Context) (model.AuditFilter, error) {
+	filter := model.AuditFilter{
+		EntityType: model.AuditEntity(c.Query("entity_type")),
+		Actor:      c.Query("actor"),
+	}
+	if filter.EntityType != "" && !filter.EntityType.Valid() {
+		return filter, &paramError{name: "entity_type", msg: "must be one of cat, mission, target"}
+	}
+
+	var err error
+	if filter.EntityID, err = queryInt(c, "entity_id"); err != nil {
+		return filter, err
+	}
+	if filter.From, err = queryTime(c, "from"); err != nil {
+		return filter, err
+	}
+	if filter.To, err = queryTime(c, "to"); err != nil {
+		return filter, err
+	}
+	cursor, err := queryInt(c, "cursor")
+	if err != nil {
+		return filter, err
+	}
+	if cursor != nil {
+		filter.AfterID = *cursor
+	}
+	if filter.Limit, err = queryLimit(c); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"fmt"
+	"main/internal/catalog"
+	"main/internal/model"
+	"main/internal/repositories"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BreedHandler struct {
+	Breeds  *catalog.BreedCatalog
+	CatRepo repositories.CatStore
+}
+
+func NewBreedHandler(breeds *catalog.BreedCatalog, catRepo repositories.CatStore) *BreedHandler {
+	return &BreedHandler{Breeds: breeds, CatRepo: catRepo}
+}
+
+// GetAllBreeds godoc
+// @Summary List cat breeds
+// @Description Lists the breeds accepted when creating a cat, optionally filtered by a name prefix. The prefix is matched case-insensitively against breed names and alternative names.
+// @Tags breeds
+// @Produce json
+// @Param search query string false "Breed name prefix"
+// @Success 200 {array} model.Breed "List of breeds"
+// @Router /breeds [get]
+func (h *BreedHandler) GetAllBreeds(c *gin.Context) {
+	breeds := h.Breeds.Breeds()
+	if search := c.Query("search"); search != "" {
+		breeds = h.Breeds.Search(search)
+	}
+	if breeds == nil {
+		breeds = []model.Breed{}
+	}
+	c.JSON(http.StatusOK, breeds)
+}
+
+// GetBreedByID godoc
+// @Summary Get a breed by ID
+// @Description Retrieves a single breed by its TheCatAPI ID
+// @Tags breeds
+// @Produce json,application/problem+json
+// @Param id path string true "Breed ID"
+// @Success 200 {object} model.Breed "Breed details"
+// @Failure 404 {object} model.Problem "Breed not found"
+// @Router /breeds/{id} [get]
+func (h *BreedHandler) GetBreedByID(c *gin.Context) {
+	breed, ok := h.Breeds.Get(c.Param("id"))
+	if !ok {
+		writeProblem(c, http.StatusNotFound, codeBreedNotFound, "Breed not found", fmt.Sprintf("breed not found: id %s", c.Param("id")))
+		return
+	}
+
+	c.JSON(http.StatusOK, breed)
+}
+
+// GetBreedCats godoc
+// @Summary List spy cats of a breed
+// @Description Retrieves all spy cats of the breed with the given TheCatAPI ID
+// @Tags breeds
+// @Produce json,application/problem+json
+// @Param id path string true "Breed ID"
+// @Success 200 {array} model.SpyCat "List of cats"
+// @Failure 404 {object} model.Problem "Breed not found"
+// @Failure 500 {object} model.Problem "Failed to retrieve cats"
+// @Router /breeds/{id}/cats [get]
+func (h *BreedHandler) GetBreedCats(c *gin.Context) {
+	breed, ok := h.Breeds.Get(c.Param("id"))
+	if !ok {
+		writeProblem(c, http.StatusNotFound, codeBreedNotFound, "Breed not found", fmt.Sprintf("breed not found: id %s", c.Param("id")))
+		return
+	}
+
+	cats, err := h.CatRepo.GetByBreed(c.Request.Context(), breed.Name)
+	if err != nil {
+		respondError(c, err, "Failed to retrieve cats")
+		return
+	}
+
+	c.JSON(http.StatusOK, cats)
+}
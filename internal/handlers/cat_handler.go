@@ -1,57 +1,58 @@
 package handlers
 
 import (
-	"encoding/json"
-	"errors"
-	"io/ioutil"
+	"cmp"
+	"main/internal/catalog"
 	"main/internal/model"
 	"main/internal/repositories"
 	"net/http"
+	"slices"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
 
-const catAPIURL = "https://api.thecatapi.com/v1/breeds"
-
-type CatBreed struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
-}
-
 type CatHandler struct {
-	CatRepo *repositories.CatRepository
+	CatRepo repositories.CatStore
+	Breeds  *catalog.BreedCatalog
 }
 
-func NewCatHandler(catRepo *repositories.CatRepository) *CatHandler {
-	return &CatHandler{CatRepo: catRepo}
+func NewCatHandler(catRepo repositories.CatStore, breeds *catalog.BreedCatalog) *CatHandler {
+	return &CatHandler{CatRepo: catRepo, Breeds: breeds}
 }
 
 // @Summary Create a new cat
-// @Description Create a new cat with breed validation
+// @Description Create a new cat. The breed is matched case-insensitively against the breed catalog, including alternative names, and stored under its canonical name.
 // @Tags cats
 // @Accept json
-// @Produce json
-// @Param cat body model.SpyCat true "Cat data"
+// @Produce json,application/problem+json
+// @Param cat body model.CreateCatRequest true "Cat data"
 // @Success 201 {object} model.SpyCat
-// @Failure 400 {object} map[string]interface{} "Invalid request body or breed"
-// @Failure 500 {object} map[string]interface{} "Failed to create cat"
+// @Failure 400 {object} model.Problem "Malformed request body"
+// @Failure 422 {object} model.Problem "Invalid fields or unknown breed"
+// @Failure 500 {object} model.Problem "Failed to create cat"
 // @Router /cat [post]
 func (h *CatHandler) CreateCat(c *gin.Context) {
-	var cat model.SpyCat
-	if err := c.ShouldBindJSON(&cat); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+	var request model.CreateCatRequest
+	fields, ok := decodeJSON(c, &request)
+	if !ok {
 		return
 	}
-
-	if err := validateBreed(cat.Breed); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	// Only look up breeds that passed the field rules
+	breed, err := h.Breeds.Validate(request.Breed)
+	if err != nil && !slices.ContainsFunc(fields, func(f model.FieldError) bool { return f.Field == "breed" }) {
+		fields = append(fields, model.FieldError{Field: "breed", Message: "is not a known breed"})
+	}
+	if len(fields) > 0 {
+		invalidFields(c, fields...)
 		return
 	}
 
-	err := h.CatRepo.Create(&cat)
+	cat := request.Cat()
+	cat.Breed = breed
+	err = h.CatRepo.Create(c.Request.Context(), &cat)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create cat"})
+		respondError(c, err, "Failed to create cat")
 		return
 	}
 
@@ -59,138 +60,349 @@ func (h *CatHandler) CreateCat(c *gin.Context) {
 }
 
 // @Summary Get all cats
-// @Description Get a list of all cats
+// @Description Get a page of cats, optionally filtered and sorted. The total number of matching cats is returned in the X-Total-Count header and links to neighbouring pages in the Link header.
 // @Tags cats
-// @Produce json
+// @Produce json,application/problem+json
+// @Param name query string false "Case-insensitive substring of the cat name"
+// @Param breed query string false "Breed name, ID or alternative name"
+// @Param min_experience query int false "Minimum years of experience"
+// @Param max_experience query int false "Maximum years of experience"
+// @Param min_salary query number false "Minimum salary"
+// @Param max_salary query number false "Maximum salary"
+// @Param sort query string false "Comma separated fields (id, name, experience_in_years, breed, salary); prefix with - for descending order"
+// @Param limit query int false "Page size (1-100)" default(50)
+// @Param offset query int false "Number of cats to skip" default(0)
 // @Success 200 {array} model.SpyCat
-// @Failure 500 {object} map[string]interface{}
+// @Header 200 {integer} X-Total-Count "Total number of matching cats"
+// @Header 200 {string} Link "Links to the next and previous pages"
+// @Failure 400 {object} model.Problem "Invalid query parameter"
+// @Failure 500 {object} model.Problem
 // @Router /cat [get]
 func (h *CatHandler) GetAllCats(c *gin.Context) {
-	cats, err := h.CatRepo.GetAll()
+	filter, err := h.parseCatFilter(c)
+	if err != nil {
+		badQuery(c, err)
+		return
+	}
+
+	cats, total, err := h.CatRepo.GetAll(c.Request.Context(), filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve cats"})
+		respondError(c, err, "Failed to retrieve cats")
 		return
 	}
+
+	setPageLinks(c, filter.Limit, filter.Offset, total)
 	c.JSON(http.StatusOK, cats)
 }
 
+// parseCatFilter reads the cat listing query parameters
+func (h *CatHandler) parseCatFilter(c *gin.Context) (model.CatFilter, error) {
+	filter := model.CatFilter{
+		Name:  c.Query("name"),
+		Breed: c.Query("breed"),
+		Sort:  c.Query("sort"),
+	}
+	if breed, ok := h.Breeds.Lookup(filter.Breed); ok {
+		filter.Breed = breed.Name
+	}
+
+	var err error
+	if filter.MinExperience, err = queryInt(c, "min_experience"); err != nil {
+		return filter, err
+	}
+	if filter.MaxExperience, err = queryInt(c, "max_experience"); err != nil {
+		return filter, err
+	}
+	if filter.MinSalary, err = queryMoney(c, "min_salary"); err != nil {
+		return filter, err
+	}
+	if filter.MaxSalary, err = queryMoney(c, "max_salary"); err != nil {
+		return filter, err
+	}
+	if filter.Limit, err = queryLimit(c); err != nil {
+		return filter, err
+	}
+	offset, err := queryInt(c, "offset")
+	if err != nil {
+		return filter, err
+	}
+	if offset != nil {
+		if *offset < 0 {
+			return filter, &paramError{name: "offset", msg: "must not be negative"}
+		}
+		filter.Offset = *offset
+	}
+	return filter, nil
+}
+
 // GetCatByID godoc
 // @Summary Get a single spy cat by ID
 // @Description Get a single spy cat by its ID
 // @Tags cats
-// @Produce json
+// @Produce json,application/problem+json
 // @Param id path int true "Cat ID"
+// @Param If-None-Match header string false "ETag of a cached copy of the cat"
 // @Success 200 {object} model.SpyCat
-// @Failure 400 {object} map[string]interface{} "Invalid cat ID"
-// @Failure 404 {object} map[string]interface{} "Cat not found"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Header 200 {string} ETag "Version of the cat"
+// @Success 304 "Cached copy is up to date"
+// @Failure 400 {object} model.Problem "Invalid cat ID"
+// @Failure 404 {object} model.Problem "Cat not found"
+// @Failure 500 {object} model.Problem "Failed to retrieve cat"
 // @Router /cat/{id} [get]
 func (h *CatHandler) GetCatByID(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cat ID"})
+		invalidParam(c, "id", "must be an integer")
 		return
 	}
 
-	cat, err := h.CatRepo.GetByID(id)
+	cat, err := h.CatRepo.GetByID(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Cat not found"})
+		respondError(c, err, "Failed to retrieve cat")
+		return
+	}
+	if notModified(c, cat.Version) {
 		return
 	}
 
 	c.JSON(http.StatusOK, cat)
 }
 
+// ReplaceCat godoc
+// @Summary Replace a spy cat
+// @Description Replace all fields of a spy cat. A changed breed is validated against the breed catalog and stored under its canonical name.
+// @Tags cats
+// @Accept json
+// @Produce json,application/problem+json
+// @Param id path int true "Cat ID"
+// @Param cat body model.CreateCatRequest true "Cat data"
+// @Param If-Match header string false "ETag of the cat; the request fails if the cat has changed since"
+// @Success 200 {object} model.SpyCat
+// @Header 200 {string} ETag "New version of the cat"
+// @Failure 400 {object} model.Problem "Invalid cat ID or malformed request body"
+// @Failure 404 {object} model.Problem "Cat not found"
+// @Failure 412 {object} model.Problem "Cat has been modified"
+// @Failure 422 {object} model.Problem "Invalid fields or unknown breed"
+// @Failure 500 {object} model.Problem "Failed to update cat"
+// @Router /cat/{id} [put]
+func (h *CatHandler) ReplaceCat(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		invalidParam(c, "id", "must be an integer")
+		return
+	}
+	version, ok := ifMatch(c)
+	if !ok {
+		return
+	}
+
+	cat, err := h.CatRepo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err, "Failed to retrieve cat")
+		return
+	}
+
+	var request model.CreateCatRequest
+	fields, ok := decodeJSON(c, &request)
+	if !ok {
+		return
+	}
+	h.updateCat(c, cat, request, fields, version)
+}
+
+// PatchCat godoc
+// @Summary Partially update a spy cat
+// @Description Update a spy cat with a JSON Merge Patch (RFC 7396). Only the fields present in the patch are changed; the patched cat must satisfy the same rules as a new one. A changed breed is validated against the breed catalog.
+// @Tags cats
+// @Accept application/merge-patch+json,json
+// @Produce json,application/problem+json
+// @Param id path int true "Cat ID"
+// @Param patch body model.CreateCatRequest true "Fields to change"
+// @Param If-Match header string false "ETag of the cat; the request fails if the cat has changed since"
+// @Success 200 {object} model.SpyCat
+// @Header 200 {string} ETag "New version of the cat"
+// @Failure 400 {object} model.Problem "Invalid cat ID or malformed patch"
+// @Failure 404 {object} model.Problem "Cat not found"
+// @Failure 412 {object} model.Problem "Cat has been modified, possibly while the patch was applied"
+// @Failure 415 {object} model.Problem "Unsupported content type"
+// @Failure 422 {object} model.Problem "Invalid fields or unknown breed"
+// @Failure 500 {object} model.Problem "Failed to update cat"
+// @Router /cat/{id} [patch]
+func (h *CatHandler) PatchCat(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		invalidParam(c, "id", "must be an integer")
+		return
+	}
+	if !isMergePatch(c.ContentType()) {
+		writeProblem(c, http.StatusUnsupportedMediaType, codeUnsupportedMedia, "",
+			"the request body must be "+mergePatchContentType)
+		return
+	}
+	version, ok := ifMatch(c)
+	if !ok {
+		return
+	}
+
+	cat, err := h.CatRepo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err, "Failed to retrieve cat")
+		return
+	}
+	patch, err := readMergePatch(c.Request.Body)
+	if err != nil {
+		invalidBody(c, err)
+		return
+	}
+
+	// Apply the patch to the JSON form of the cat and decode the result as a full replacement
+	var document map[string]any
+	if err := remarshal(cat, &document); err != nil {
+		respondError(c, err, "Failed to update cat")
+		return
+	}
+	delete(document, "id")
+	var request model.CreateCatRequest
+	if err := remarshal(mergePatch(document, patch), &request); err != nil {
+		invalidBody(c, err)
+		return
+	}
+	// The patch was applied to the version just read, which must still be current when saving
+	h.updateCat(c, cat, request, validate(&request), cmp.Or(version, cat.Version))
+}
+
+// updateCat replaces the fields of cat with the validated request if the cat still has
+// the given version, and responds with the result
+func (h *CatHandler) updateCat(c *gin.Context, cat *model.SpyCat, request model.CreateCatRequest, fields []model.FieldError, version int) {
+	// Breeds are checked only when they change, so cats keep breeds that have since left the catalog
+	breed := cat.Breed
+	if request.Breed != cat.Breed && !slices.ContainsFunc(fields, func(f model.FieldError) bool { return f.Field == "breed" }) {
+		var err error
+		if breed, err = h.Breeds.Validate(request.Breed); err != nil {
+			fields = append(fields, model.FieldError{Field: "breed", Message: "is not a known breed"})
+		}
+	}
+	if len(fields) > 0 {
+		invalidFields(c, fields...)
+		return
+	}
+
+	updated := request.Cat()
+	updated.ID = cat.ID
+	updated.Breed = breed
+	if err := h.CatRepo.Update(c.Request.Context(), &updated, version); err != nil {
+		respondError(c, err, "Failed to update cat")
+		return
+	}
+
+	c.Header("ETag", etag(updated.Version))
+	c.JSON(http.StatusOK, updated)
+}
+
 // UpdateCatSalary godoc
 // @Summary Update cat's salary
-// @Description Update the salary of a spy cat by its ID
+// @Description Update the salary of a spy cat by its ID. The change is recorded in the salary history, approved by the actor from the X-Actor header, and takes effect on effective_from, today by default. It may be backdated, but neither into the future nor before the previous change.
 // @Tags cats
-// @Produce json
+// @Produce json,application/problem+json
 // @Param id path int true "Cat ID"
 // @Param salary body model.SalaryUpdate true "Salary data"
+// @Param If-Match header string false "ETag of the cat; the request fails if the cat has changed since"
 // @Success 200 {object} map[string]interface{} "Salary updated successfully"
-// @Failure 400 {object} map[string]interface{} "Invalid cat ID or request body"
-// @Failure 500 {object} map[string]interface{} "Failed to update salary"
+// @Failure 400 {object} model.Problem "Invalid cat ID or malformed request body"
+// @Failure 404 {object} model.Problem "Cat not found"
+// @Failure 412 {object} model.Problem "Cat has been modified"
+// @Failure 422 {object} model.Problem "Salary missing or out of range, or invalid effective date"
+// @Failure 500 {object} model.Problem "Failed to update salary"
 // @Router /cat/{id}/salary [put]
 func (h *CatHandler) UpdateCatSalary(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cat ID"})
+		invalidParam(c, "id", "must be an integer")
+		return
+	}
+
+	version, ok := ifMatch(c)
+	if !ok {
 		return
 	}
 
 	var updateData model.SalaryUpdate
-	if err := c.ShouldBindJSON(&updateData); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+	if !bindJSON(c, &updateData) {
 		return
 	}
 
-	err = h.CatRepo.UpdateSalary(id, updateData.Salary)
+	change := model.SalaryChange{CatID: id, Salary: *updateData.Salary, Reason: updateData.Reason}
+	if updateData.EffectiveFrom != "" {
+		// The binding has already checked the format
+		change.EffectiveFrom, _ = model.ParseDate(updateData.EffectiveFrom)
+	}
+	err = h.CatRepo.UpdateSalary(c.Request.Context(), &change, version)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update salary"})
+		respondError(c, err, "Failed to update salary")
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Salary updated successfully"})
 }
 
-// DeleteCat godoc
-// @Summary Delete a spy cat
-// @Description Delete a spy cat by its ID
+// GetSalaryHistory godoc
+// @Summary Get a cat's salary history
+// @Description Retrieves the salary changes of a spy cat in the order they take effect, including the initial salary and, for a deleted cat, the change to zero on deletion
 // @Tags cats
-// @Produce json
+// @Produce json,application/problem+json
 // @Param id path int true "Cat ID"
-// @Success 200 {object} map[string]interface{} "Cat deleted successfully"
-// @Failure 400 {object} map[string]interface{} "Invalid cat ID"
-// @Failure 500 {object} map[string]interface{} "Failed to delete cat"
-// @Router /cat/{id} [delete]
-func (h *CatHandler) DeleteCat(c *gin.Context) {
+// @Success 200 {array} model.SalaryChange "Salary history"
+// @Failure 400 {object} model.Problem "Invalid cat ID"
+// @Failure 404 {object} model.Problem "Cat not found"
+// @Failure 500 {object} model.Problem "Failed to retrieve salary history"
+// @Router /cat/{id}/salary-history [get]
+func (h *CatHandler) GetSalaryHistory(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cat ID"})
+		invalidParam(c, "id", "must be an integer")
 		return
 	}
 
-	err = h.CatRepo.Delete(id)
+	changes, err := h.CatRepo.GetSalaryHistory(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete cat"})
+		respondError(c, err, "Failed to retrieve salary history")
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Cat deleted successfully"})
+	c.JSON(http.StatusOK, changes)
 }
 
-// ValidateBreed checks if the given breed is valid by comparing it to the list of breeds from TheCatAPI
-func validateBreed(breed string) error {
-	// Make the HTTP request to TheCatAPI
-	resp, err := http.Get(catAPIURL)
+// DeleteCat godoc
+// @Summary Delete a spy cat
+// @Description Delete a spy cat by its ID
+// @Tags cats
+// @Produce json,application/problem+json
+// @Param id path int true "Cat ID"
+// @Param If-Match header string false "ETag of the cat; the request fails if the cat has changed since"
+// @Success 200 {object} map[string]interface{} "Cat deleted successfully"
+// @Failure 400 {object} model.Problem "Invalid cat ID"
+// @Failure 404 {object} model.Problem "Cat not found"
+// @Failure 409 {object} model.Problem "Cat has an open mission"
+// @Failure 412 {object} model.Problem "Cat has been modified"
+// @Failure 500 {object} model.Problem "Failed to delete cat"
+// @Router /cat/{id} [delete]
+func (h *CatHandler) DeleteCat(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return errors.New("failed to retrieve cat breeds")
+		invalidParam(c, "id", "must be an integer")
+		return
 	}
 
-	// Read the response body
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return err
+	version, ok := ifMatch(c)
+	if !ok {
+		return
 	}
 
-	var breeds []CatBreed
-	err = json.Unmarshal(body, &breeds)
+	err = h.CatRepo.Delete(c.Request.Context(), id, version)
 	if err != nil {
-		return err
-	}
-
-	// Check if the breed exists in the list of valid breeds
-	for _, b := range breeds {
-		if b.Name == breed {
-			return nil // breed is valid
-		}
+		respondError(c, err, "Failed to delete cat")
+		return
 	}
 
-	return errors.New("invalid breed")
+	c.JSON(http.StatusOK, gin.H{"message": "Cat deleted successfully"})
 }
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"main/internal/catalog"
+	"main/internal/model"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CountryHandler struct {
+	Countries *catalog.CountryRegistry
+}
+
+func NewCountryHandler(countries *catalog.CountryRegistry) *CountryHandler {
+	return &CountryHandler{Countries: countries}
+}
+
+// GetAllCountries godoc
+// @Summary List countries
+// @Description Lists the ISO 3166-1 countries accepted as target countries, optionally filtered by a prefix of their code, name or alternative name. Targets store countries as alpha-2 codes.
+// @Tags countries
+// @Produce json
+// @Param search query string false "Country code or name prefix"
+// @Success 200 {array} model.Country "List of countries"
+// @Router /countries [get]
+func (h *CountryHandler) GetAllCountries(c *gin.Context) {
+	countries := h.Countries.Countries()
+	if search := c.Query("search"); search != "" {
+		countries = h.Countries.Search(search)
+	}
+	if countries == nil {
+		countries = []model.Country{}
+	}
+	c.JSON(http.StatusOK, countries)
+}
@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"main/internal/apperr"
+	"main/internal/model"
+	"main/internal/repositories"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	problemContentType = "application/problem+json"
+	// problemTypePrefix is followed by the problem code to form the problem type URI
+	problemTypePrefix = "urn:spy-cats:problem:"
+)
+
+// Codes of the problems detected by the handlers themselves
+const (
+	codeInvalidParameter = "invalid_parameter"
+	codeInvalidBody      = "invalid_body"
+	codeValidationFailed = "validation_failed"
+	codeRouteNotFound    = "route_not_found"
+	codeBreedNotFound    = "breed_not_found"
+	codeUnsupportedMedia = "unsupported_media_type"
+	codeInternal         = "internal_error"
+)
+
+// errorStatuses maps domain errors to response statuses, most specific first.
+// The code is used for errors that do not carry their own.
+var errorStatuses = []struct {
+	err    error
+	status int
+	code   string
+}{
+	{repositories.ErrInvalidFilter, http.StatusBadRequest, codeInvalidParameter},
+	{apperr.ErrNotFound, http.StatusNotFound, "not_found"},
+	{apperr.ErrConflict, http.StatusConflict, "conflict"},
+	{apperr.ErrForbiddenState, http.StatusConflict, "forbidden_state"},
+	{apperr.ErrValidation, http.StatusUnprocessableEntity, codeValidationFailed},
+	{apperr.ErrPreconditionFailed, http.StatusPreconditionFailed, "precondition_failed"},
+}
+
+// codedError is implemented by domain errors with a stable code
+type codedError interface {
+	error
+	Code() string
+}
+
+// respondError responds to a failed operation. Domain errors are reported with
+// their own status, code and message; any other error is logged and answered
+// with 500 and the fallback message, so store internals are not exposed.
+func respondError(c *gin.Context, err error, fallback string) {
+	for _, mapping := range errorStatuses {
+		if !errors.Is(err, mapping.err) {
+			continue
+		}
+		code, title := mapping.code, ""
+		var coded codedError
+		if errors.As(err, &coded) {
+			code, title = coded.Code(), capitalize(coded.Error())
+		}
+		writeProblem(c, mapping.status, code, title, err.Error())
+		return
+	}
+
+	log.Printf("%s %s: %v", c.Request.Method, c.Request.URL.Path, err)
+	writeProblem(c, http.StatusInternalServerError, codeInternal, "", fallback)
+}
+
+// invalidParam responds with 400 for an invalid path or query parameter
+func invalidParam(c *gin.Context, name, msg string) {
+	writeProblem(c, http.StatusBadRequest, codeInvalidParameter, "Invalid parameter",
+		fmt.Sprintf("invalid %s: %s", name, msg), model.FieldError{Field: name, Message: msg})
+}
+
+// invalidBody responds with 400 for a request body that cannot be decoded
+func invalidBody(c *gin.Context, err error) {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		msg := "must be " + jsonType(typeErr.Type)
+		writeProblem(c, http.StatusBadRequest, codeInvalidBody, "Invalid request body",
+			fmt.Sprintf("invalid %s: %s", typeErr.Field, msg), model.FieldError{Field: typeErr.Field, Message: msg})
+		return
+	}
+	if errors.Is(err, model.ErrInvalidMoney) {
+		writeProblem(c, http.StatusBadRequest, codeInvalidBody, "Invalid request body", err.Error())
+		return
+	}
+	writeProblem(c, http.StatusBadRequest, codeInvalidBody, "Invalid request body", "the request body is not valid JSON")
+}
+
+// invalidFields responds with 422 listing the fields that break the API rules
+func invalidFields(c *gin.Context, fields ...model.FieldError) {
+	details := make([]string, len(fields))
+	for i, field := range fields {
+		details[i] = field.Field + " " + field.Message
+	}
+	writeProblem(c, http.StatusUnprocessableEntity, codeValidationFailed, "Validation failed",
+		strings.Join(details, "; "), fields...)
+}
+
+// writeProblem responds with RFC 7807 problem details. An empty title defaults to the status text.
+func writeProblem(c *gin.Context, status int, code, title, detail string, fields ...model.FieldError) {
+	if title == "" {
+		title = http.StatusText(status)
+	}
+	c.Header("Content-Type", problemContentType)
+	c.AbortWithStatusJSON(status, model.Problem{
+		Type:     problemTypePrefix + code,
+		Title:    title,
+		Status:   status,
+		Detail:   detail,
+		Instance: c.Request.URL.Path,
+		Code:     code,
+		Errors:   fields,
+	})
+}
+
+// NotFound responds to requests for unknown routes
+func NotFound(c *gin.Context) {
+	writeProblem(c, http.StatusNotFound, codeRouteNotFound, "Route not found",
+		fmt.Sprintf("no route for %s %s", c.Request.Method, c.Request.URL.Path))
+}
+
+// Recovery responds to requests whose handler panicked; gin logs the panic itself
+func Recovery(c *gin.Context, recovered any) {
+	writeProblem(c, http.StatusInternalServerError, codeInternal, "", "the request could not be processed")
+}
+
+// jsonType names the JSON type a Go type is decoded from
+func jsonType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.String:
+		return "a string"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	}
+	return "an object"
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"fmt"
+	"main/internal/repositories"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// etag formats the version of a resource as a strong entity tag
+func etag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// ifMatch returns the version required by the If-Match header, or 0 when any
+// version will do. It responds and returns false when the header is malformed
+// or names a tag no version can match, such as a weak one.
+func ifMatch(c *gin.Context) (int, bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return 0, true
+	}
+	if strings.Contains(header, ",") {
+		invalidParam(c, "If-Match", "must be a single entity tag or *")
+		return 0, false
+	}
+
+	version, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(header, `"`), `"`))
+	if err != nil || version <= 0 || !strings.HasPrefix(header, `"`) || !strings.HasSuffix(header, `"`) {
+		respondError(c, fmt.Errorf("%w: no version matches %s", repositories.ErrVersionMismatch, header), "")
+		return 0, false
+	}
+	return version, true
+}
+
+// notModified sets the ETag header of a resource read. It responds with 304
+// and returns true when the If-None-Match header lists the tag, comparing weakly.
+func notModified(c *gin.Context, version int) bool {
+	tag := etag(version)
+	c.Header("ETag", tag)
+	header := c.GetHeader("If-None-Match")
+	if header == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == tag {
+			c.AbortWithStatus(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+)
+
+const mergePatchContentType = "application/merge-patch+json"
+
+// isMergePatch reports whether the request body is declared as a JSON Merge Patch.
+// Plain JSON is accepted as well, since a merge patch is a regular JSON document.
+func isMergePatch(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (mediaType == mergePatchContentType || mediaType == "application/json")
+}
+
+// readMergePatch decodes a JSON Merge Patch document. Numbers are kept as
+// json.Number, so amounts such as salaries reach model.Money exactly.
+func readMergePatch(body io.Reader) (any, error) {
+	var patch any
+	if err := decodeNumbers(body, &patch); err != nil {
+		return nil, err
+	}
+	return patch, nil
+}
+
+// mergePatch applies a JSON Merge Patch (RFC 7396) to a decoded JSON document.
+// Members set to null are removed, objects are merged recursively and any
+// other value replaces the target.
+func mergePatch(target, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	targetObject, ok := target.(map[string]any)
+	if !ok {
+		targetObject = map[string]any{}
+	}
+	for name, value := range patchObject {
+		if value == nil {
+			delete(targetObject, name)
+			continue
+		}
+		targetObject[name] = mergePatch(targetObject[name], value)
+	}
+	return targetObject
+}
+
+// remarshal converts between JSON compatible values by encoding src and decoding it into dst
+func remarshal(src, dst any) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return decodeNumbers(bytes.NewReader(data), dst)
+}
+
+// decodeNumbers decodes a JSON document into v, keeping numbers decoded into
+// an interface as json.Number rather than float64
+func decodeNumbers(r io.Reader, v any) error {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
@@ -1,43 +1,62 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"main/internal/catalog"
 	"main/internal/model"
 	"main/internal/repositories"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
 
 type MissionHandler struct {
-	MissionRepo *repositories.MissionRepository
+	MissionRepo repositories.MissionStore
+	Countries   *catalog.CountryRegistry
 }
 
-func NewMissionHandler(missionRepo *repositories.MissionRepository) *MissionHandler {
-	return &MissionHandler{MissionRepo: missionRepo}
+func NewMissionHandler(missionRepo repositories.MissionStore, countries *catalog.CountryRegistry) *MissionHandler {
+	return &MissionHandler{MissionRepo: missionRepo, Countries: countries}
 }
 
 // CreateMission godoc
 // @Summary Create a mission with targets
-// @Description Create a new mission and its associated targets
+// @Description Create a new mission and its associated targets. A mission has between one and three targets by default. Target countries may be given by name or ISO 3166 alpha-2 or alpha-3 code and are stored as alpha-2 codes.
 // @Tags missions
 // @Accept json
-// @Produce json
-// @Param mission body model.Mission true "Mission details with targets"
+// @Produce json,application/problem+json
+// @Param mission body model.CreateMissionRequest true "Mission details with targets"
 // @Success 201 {object} model.Mission "Mission created successfully"
-// @Failure 400 {object} map[string]interface{} "Invalid request body"
-// @Failure 500 {object} map[string]interface{} "Failed to create mission"
+// @Failure 400 {object} model.Problem "Malformed request body"
+// @Failure 404 {object} model.Problem "Cat not found"
+// @Failure 409 {object} model.Problem "Cat already has an active mission"
+// @Failure 422 {object} model.Problem "Invalid fields or number of targets"
+// @Failure 500 {object} model.Problem "Failed to create mission"
 // @Router /mission [post]
 func (h *MissionHandler) CreateMission(c *gin.Context) {
-	var mission model.Mission
-	if err := c.ShouldBindJSON(&mission); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+	var request model.CreateMissionRequest
+	fields, ok := decodeJSON(c, &request)
+	if !ok {
+		return
+	}
+	mission := request.Mission()
+	for i := range mission.Targets {
+		field := fmt.Sprintf("targets[%d].country", i)
+		fields = h.normalizeCountry(&mission.Targets[i], field, fields)
+	}
+	if len(fields) > 0 {
+		invalidFields(c, fields...)
 		return
 	}
 
-	err := h.MissionRepo.Create(&mission)
+	err := h.MissionRepo.Create(c.Request.Context(), &mission)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create mission"})
+		respondError(c, err, "Failed to create mission")
 		return
 	}
 
@@ -46,24 +65,33 @@ func (h *MissionHandler) CreateMission(c *gin.Context) {
 
 // DeleteMission godoc
 // @Summary Delete a mission (only if it’s not assigned to a cat)
-// @Description Delete a mission, but only if it's not assigned to a cat. Returns an error if the mission is assigned to a cat.
+// @Description Delete a mission, but only if it's not assigned to a cat. Returns an error if the mission is assigned to a cat; unassign the cat first. Completed and aborted missions can be unassigned too, keeping their status, and then deleted.
 // @Tags missions
-// @Produce json
+// @Produce json,application/problem+json
 // @Param id path int true "Mission ID"
+// @Param If-Match header string false "ETag of the mission; the request fails if the mission has changed since"
 // @Success 200 {object} map[string]interface{} "Mission deleted successfully"
-// @Failure 400 {object} map[string]interface{} "Invalid mission ID"
-// @Failure 500 {object} map[string]interface{} "Failed to delete mission"
+// @Failure 400 {object} model.Problem "Invalid mission ID"
+// @Failure 404 {object} model.Problem "Mission not found"
+// @Failure 409 {object} model.Problem "Mission is assigned to a cat"
+// @Failure 412 {object} model.Problem "Mission has been modified"
+// @Failure 500 {object} model.Problem "Failed to delete mission"
 // @Router /mission/{id} [delete]
 func (h *MissionHandler) DeleteMission(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid mission ID"})
+		invalidParam(c, "id", "must be an integer")
+		return
+	}
+
+	version, ok := ifMatch(c)
+	if !ok {
 		return
 	}
 
-	err = h.MissionRepo.Delete(id)
+	err = h.MissionRepo.Delete(c.Request.Context(), id, version)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete mission"})
+		respondError(c, err, "Failed to delete mission")
 		return
 	}
 
@@ -72,24 +100,46 @@ func (h *MissionHandler) DeleteMission(c *gin.Context) {
 
 // CompleteMission godoc
 // @Summary Mark a mission as complete
-// @Description Mark a mission as completed in the system.
+// @Description Mark a mission as completed in the system. Only missions in progress can be completed, and only once all their targets are complete unless completion is forced with a reason.
 // @Tags missions
-// @Produce json
+// @Accept json
+// @Produce json,application/problem+json
 // @Param id path int true "Mission ID"
+// @Param request body model.CompleteMissionRequest false "Force completion of a mission with open targets"
+// @Param If-Match header string false "ETag of the mission; the request fails if the mission has changed since"
 // @Success 200 {object} map[string]interface{} "Mission marked as complete"
-// @Failure 400 {object} map[string]interface{} "Invalid mission ID"
-// @Failure 500 {object} map[string]interface{} "Failed to complete mission"
+// @Failure 400 {object} model.Problem "Invalid mission ID or malformed request body"
+// @Failure 404 {object} model.Problem "Mission not found"
+// @Failure 409 {object} model.Problem "Mission cannot be completed in its current status or has open targets"
+// @Failure 412 {object} model.Problem "Mission has been modified"
+// @Failure 422 {object} model.Problem "Forced completion without a reason"
+// @Failure 500 {object} model.Problem "Failed to complete mission"
 // @Router /mission/{id}/complete [put]
 func (h *MissionHandler) CompleteMission(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid mission ID"})
+		invalidParam(c, "id", "must be an integer")
+		return
+	}
+
+	version, ok := ifMatch(c)
+	if !ok {
+		return
+	}
+
+	var request model.CompleteMissionRequest
+	if err := c.ShouldBindJSON(&request); err != nil && !errors.Is(err, io.EOF) {
+		invalidBody(c, err)
+		return
+	}
+	if request.Force && strings.TrimSpace(request.Reason) == "" {
+		invalidFields(c, model.FieldError{Field: "reason", Message: "is required to force completion"})
 		return
 	}
 
-	err = h.MissionRepo.Update(id)
+	err = h.MissionRepo.Complete(c.Request.Context(), id, request.Force, request.Reason, version)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to complete mission"})
+		respondError(c, err, "Failed to complete mission")
 		return
 	}
 
@@ -100,29 +150,38 @@ func (h *MissionHandler) CompleteMission(c *gin.Context) {
 // @Summary Update notes for a target (only if not completed)
 // @Description Update the notes for a mission target if it has not been marked as complete.
 // @Tags missions
-// @Produce json
+// @Produce json,application/problem+json
 // @Param target_id path int true "Target ID"
 // @Param notes body model.NoteUpdate true "Updated notes"
+// @Param If-Match header string false "Version of the target as an entity tag, e.g. \"2\"; the request fails if the target has changed since"
 // @Success 200 {object} map[string]interface{} "Notes updated successfully"
-// @Failure 400 {object} map[string]interface{} "Invalid target ID or request body"
-// @Failure 500 {object} map[string]interface{} "Failed to update notes"
+// @Failure 400 {object} model.Problem "Invalid target ID or malformed request body"
+// @Failure 404 {object} model.Problem "Target not found"
+// @Failure 409 {object} model.Problem "Target or mission is completed"
+// @Failure 412 {object} model.Problem "Target has been modified"
+// @Failure 422 {object} model.Problem "Notes missing"
+// @Failure 500 {object} model.Problem "Failed to update notes"
 // @Router /mission/targets/{target_id}/notes [put]
 func (h *MissionHandler) UpdateTargetNotes(c *gin.Context) {
 	targetID, err := strconv.Atoi(c.Param("target_id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid target ID"})
+		invalidParam(c, "target_id", "must be an integer")
+		return
+	}
+
+	version, ok := ifMatch(c)
+	if !ok {
 		return
 	}
 
 	var noteUpdate model.NoteUpdate
-	if err := c.ShouldBindJSON(&noteUpdate); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+	if !bindJSON(c, &noteUpdate) {
 		return
 	}
 
-	err = h.MissionRepo.UpdateNotes(targetID, noteUpdate.Notes)
+	err = h.MissionRepo.UpdateNotes(c.Request.Context(), targetID, *noteUpdate.Notes, version)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update notes"})
+		respondError(c, err, "Failed to update notes")
 		return
 	}
 
@@ -131,50 +190,69 @@ func (h *MissionHandler) UpdateTargetNotes(c *gin.Context) {
 
 // MarkTargetAsComplete godoc
 // @Summary Mark a mission target as complete
-// @Description Marks a specified mission target as complete if found.
+// @Description Marks a specified mission target as complete if found. The mission must be assigned or in progress; completing the first target starts an assigned mission and completing the last open target completes the mission, which is reported in mission_completed.
 // @Tags missions
-// @Produce json
+// @Produce json,application/problem+json
 // @Param target_id path int true "Target ID"
+// @Param If-Match header string false "Version of the target as an entity tag, e.g. \"2\"; the request fails if the target has changed since"
 // @Success 200 {object} map[string]interface{} "Target marked as complete"
-// @Failure 400 {object} map[string]interface{} "Invalid target ID"
-// @Failure 404 {object} map[string]interface{} "Target not found"
+// @Failure 400 {object} model.Problem "Invalid target ID"
+// @Failure 404 {object} model.Problem "Target not found"
+// @Failure 409 {object} model.Problem "Target is already complete or the mission is not assigned or in progress"
+// @Failure 412 {object} model.Problem "Target has been modified"
+// @Failure 500 {object} model.Problem "Failed to complete target"
 // @Router /mission/targets/{target_id}/complete [put]
 func (h *MissionHandler) MarkTargetAsComplete(c *gin.Context) {
 	targetID, err := strconv.Atoi(c.Param("target_id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid target ID"})
+		invalidParam(c, "target_id", "must be an integer")
+		return
+	}
+
+	version, ok := ifMatch(c)
+	if !ok {
 		return
 	}
 
-	err = h.MissionRepo.MarkTargetAsComplete(targetID)
+	missionCompleted, err := h.MissionRepo.MarkTargetAsComplete(c.Request.Context(), targetID, version)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		respondError(c, err, "Failed to complete target")
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "target marked as complete"})
+	c.JSON(http.StatusOK, gin.H{"message": "target marked as complete", "mission_completed": missionCompleted})
 }
 
 // DeleteTarget godoc
 // @Summary Delete a target from a mission
 // @Description Deletes a specified target from a mission by its ID.
 // @Tags missions
-// @Produce json
+// @Produce json,application/problem+json
 // @Param target_id path int true "Target ID"
+// @Param If-Match header string false "Version of the target as an entity tag, e.g. \"2\"; the request fails if the target has changed since"
 // @Success 200 {object} map[string]interface{} "Target deleted successfully"
-// @Failure 400 {object} map[string]interface{} "Invalid target ID"
-// @Failure 500 {object} map[string]interface{} "Failed to delete target"
+// @Failure 400 {object} model.Problem "Invalid target ID"
+// @Failure 404 {object} model.Problem "Target not found"
+// @Failure 409 {object} model.Problem "Target is completed"
+// @Failure 412 {object} model.Problem "Target has been modified"
+// @Failure 422 {object} model.Problem "Mission would have too few targets"
+// @Failure 500 {object} model.Problem "Failed to delete target"
 // @Router /mission/targets/{target_id} [delete]
 func (h *MissionHandler) DeleteTarget(c *gin.Context) {
 	targetID, err := strconv.Atoi(c.Param("target_id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid target ID"})
+		invalidParam(c, "target_id", "must be an integer")
+		return
+	}
+
+	version, ok := ifMatch(c)
+	if !ok {
 		return
 	}
 
-	err = h.MissionRepo.DeleteTarget(targetID)
+	err = h.MissionRepo.DeleteTarget(c.Request.Context(), targetID, version)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete target"})
+		respondError(c, err, "Failed to delete target")
 		return
 	}
 
@@ -185,30 +263,37 @@ func (h *MissionHandler) DeleteTarget(c *gin.Context) {
 // @Summary Add a target to an existing mission
 // @Description Adds a new target to a specified mission by its ID.
 // @Tags missions
-// @Produce json
+// @Produce json,application/problem+json
 // @Param id path int true "Mission ID"
-// @Param target body model.Target true "Target to add"
+// @Param target body model.CreateTargetRequest true "Target to add"
 // @Success 200 {object} map[string]interface{} "Target added successfully"
-// @Failure 400 {object} map[string]interface{} "Invalid request body"
-// @Failure 400 {object} map[string]interface{} "Invalid mission ID"
-// @Failure 500 {object} map[string]interface{} "Failed to add target"
+// @Failure 400 {object} model.Problem "Invalid mission ID or malformed request body"
+// @Failure 404 {object} model.Problem "Mission not found"
+// @Failure 409 {object} model.Problem "Mission is completed or aborted"
+// @Failure 422 {object} model.Problem "Invalid fields, or the mission already has the maximum number of targets"
+// @Failure 500 {object} model.Problem "Failed to add target"
 // @Router /mission/{id}/targets [post]
 func (h *MissionHandler) AddTarget(c *gin.Context) {
 	missionID, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid mission ID"})
+		invalidParam(c, "id", "must be an integer")
 		return
 	}
 
-	var target model.Target
-	if err := c.ShouldBindJSON(&target); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+	var request model.CreateTargetRequest
+	fields, ok := decodeJSON(c, &request)
+	if !ok {
+		return
+	}
+	target := request.Target()
+	if fields = h.normalizeCountry(&target, "country", fields); len(fields) > 0 {
+		invalidFields(c, fields...)
 		return
 	}
 
-	err = h.MissionRepo.AddTarget(missionID, &target)
+	err = h.MissionRepo.AddTarget(c.Request.Context(), missionID, &target)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add target"})
+		respondError(c, err, "Failed to add target")
 		return
 	}
 
@@ -217,76 +302,307 @@ func (h *MissionHandler) AddTarget(c *gin.Context) {
 
 // AssignCatToMission godoc
 // @Summary Assign a cat to a mission
-// @Description Assigns a specified cat to an existing mission by its ID.
+// @Description Assigns a specified cat to an existing draft mission by its ID. A cat can work on only one open mission at a time.
 // @Tags missions
-// @Produce json
+// @Produce json,application/problem+json
 // @Param id path int true "Mission ID"
-// @Param cat_id body int true "Cat ID"
+// @Param request body model.AssignCatRequest true "Cat to assign"
 // @Success 200 {object} map[string]interface{} "Cat assigned to mission"
-// @Failure 400 {object} map[string]interface{} "Invalid request body or mission ID"
-// @Failure 500 {object} map[string]interface{} "Failed to assign cat"
+// @Failure 400 {object} model.Problem "Invalid mission ID or malformed request body"
+// @Failure 404 {object} model.Problem "Mission or cat not found"
+// @Failure 409 {object} model.Problem "Mission is already assigned or not a draft, or the cat already has an active mission"
+// @Failure 422 {object} model.Problem "Cat ID missing"
+// @Failure 500 {object} model.Problem "Failed to assign cat"
 // @Router /mission/{id}/assign-cat [post]
 func (h *MissionHandler) AssignCatToMission(c *gin.Context) {
 	missionID, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid mission ID"})
+		invalidParam(c, "id", "must be an integer")
 		return
 	}
 
-	var assignData struct {
-		CatID int `json:"cat_id"`
-	}
-	if err := c.ShouldBindJSON(&assignData); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+	var assignData model.AssignCatRequest
+	if !bindJSON(c, &assignData) {
 		return
 	}
 
-	err = h.MissionRepo.AssignCat(missionID, assignData.CatID)
+	err = h.MissionRepo.AssignCat(c.Request.Context(), missionID, assignData.CatID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to assign cat"})
+		respondError(c, err, "Failed to assign cat")
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Cat assigned to mission"})
 }
 
+// UnassignCatFromMission godoc
+// @Summary Unassign the cat from a mission
+// @Description Removes the cat from an assigned mission, which returns to draft and can then be deleted or assigned to another cat. A completed or aborted mission keeps its status, so it can be deleted. Missions already in progress are handed over with reassign instead.
+// @Tags missions
+// @Accept json
+// @Produce json,application/problem+json
+// @Param id path int true "Mission ID"
+// @Param request body model.UnassignCatRequest false "Optional reason"
+// @Param If-Match header string false "ETag of the mission; the request fails if the mission has changed since"
+// @Success 200 {object} map[string]interface{} "Cat unassigned from mission"
+// @Failure 400 {object} model.Problem "Invalid request body or mission ID"
+// @Failure 404 {object} model.Problem "Mission not found"
+// @Failure 409 {object} model.Problem "Mission has no cat or is in progress"
+// @Failure 412 {object} model.Problem "Mission has been modified"
+// @Failure 500 {object} model.Problem "Failed to unassign cat"
+// @Router /mission/{id}/assign-cat [delete]
+func (h *MissionHandler) UnassignCatFromMission(c *gin.Context) {
+	missionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		invalidParam(c, "id", "must be an integer")
+		return
+	}
+
+	version, ok := ifMatch(c)
+	if !ok {
+		return
+	}
+
+	var request model.UnassignCatRequest
+	if err := c.ShouldBindJSON(&request); err != nil && !errors.Is(err, io.EOF) {
+		invalidBody(c, err)
+		return
+	}
+
+	err = h.MissionRepo.UnassignCat(c.Request.Context(), missionID, request.Reason, version)
+	if err != nil {
+		respondError(c, err, "Failed to unassign cat")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Cat unassigned from mission"})
+}
+
+// ReassignMission godoc
+// @Summary Hand a mission over to another cat
+// @Description Replaces the cat of an assigned or in-progress mission without changing its status. The reason is kept in the assignment history.
+// @Tags missions
+// @Accept json
+// @Produce json,application/problem+json
+// @Param id path int true "Mission ID"
+// @Param request body model.ReassignCatRequest true "New cat and the reason for the change"
+// @Success 200 {object} map[string]interface{} "Mission reassigned"
+// @Failure 400 {object} model.Problem "Invalid mission ID or malformed request body"
+// @Failure 404 {object} model.Problem "Mission or cat not found"
+// @Failure 409 {object} model.Problem "Mission has no cat, is closed or already assigned to the cat, or the cat already has an active mission"
+// @Failure 422 {object} model.Problem "Cat ID or reason missing"
+// @Failure 500 {object} model.Problem "Failed to reassign mission"
+// @Router /mission/{id}/reassign [post]
+func (h *MissionHandler) ReassignMission(c *gin.Context) {
+	missionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		invalidParam(c, "id", "must be an integer")
+		return
+	}
+
+	var request model.ReassignCatRequest
+	if !bindJSON(c, &request) {
+		return
+	}
+
+	err = h.MissionRepo.ReassignCat(c.Request.Context(), missionID, request.CatID, request.Reason)
+	if err != nil {
+		respondError(c, err, "Failed to reassign mission")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Mission reassigned"})
+}
+
+// GetAssignments godoc
+// @Summary Get the assignment history
+// @Description Lists which cats were assigned to which missions and when, in the order they were assigned. Open assignments have no unassigned_at.
+// @Tags missions
+// @Produce json,application/problem+json
+// @Param mission_id query int false "Only assignments of the mission"
+// @Param cat_id query int false "Only assignments of the cat"
+// @Success 200 {array} model.MissionAssignment "Assignment history"
+// @Failure 400 {object} model.Problem "Invalid query parameter"
+// @Failure 500 {object} model.Problem "Failed to retrieve assignments"
+// @Router /mission/assignments [get]
+func (h *MissionHandler) GetAssignments(c *gin.Context) {
+	var filter model.AssignmentFilter
+	var err error
+	if filter.MissionID, err = queryInt(c, "mission_id"); err != nil {
+		badQuery(c, err)
+		return
+	}
+	if filter.CatID, err = queryInt(c, "cat_id"); err != nil {
+		badQuery(c, err)
+		return
+	}
+
+	assignments, err := h.MissionRepo.GetAssignments(c.Request.Context(), filter)
+	if err != nil {
+		respondError(c, err, "Failed to retrieve assignments")
+		return
+	}
+
+	c.JSON(http.StatusOK, assignments)
+}
+
+// TransitionMission godoc
+// @Summary Change the status of a mission
+// @Description Moves a mission to another status following its lifecycle: draft → assigned → in_progress → completed, and any open mission → aborted. A mission is completed only once all its targets are complete. Cats are assigned through the assign-cat endpoint.
+// @Tags missions
+// @Accept json
+// @Produce json,application/problem+json
+// @Param id path int true "Mission ID"
+// @Param transition body model.MissionTransitionRequest true "Target status and optional reason"
+// @Success 200 {object} model.Mission "Mission after the transition"
+// @Failure 400 {object} model.Problem "Invalid mission ID or malformed request body"
+// @Failure 404 {object} model.Problem "Mission not found"
+// @Failure 409 {object} model.Problem "Transition not allowed from the current status, or completion of a mission with open targets"
+// @Failure 422 {object} model.Problem "Unknown status"
+// @Failure 500 {object} model.Problem "Failed to change mission status"
+// @Router /mission/{id}/transition [post]
+func (h *MissionHandler) TransitionMission(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		invalidParam(c, "id", "must be an integer")
+		return
+	}
+
+	var request model.MissionTransitionRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		invalidBody(c, err)
+		return
+	}
+	if !request.Status.Valid() {
+		invalidFields(c, model.FieldError{Field: "status", Message: "must be one of draft, assigned, in_progress, completed, aborted"})
+		return
+	}
+
+	err = h.MissionRepo.Transition(c.Request.Context(), id, request.Status, request.Reason)
+	if err != nil {
+		respondError(c, err, "Failed to change mission status")
+		return
+	}
+
+	mission, err := h.MissionRepo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err, "Failed to retrieve mission")
+		return
+	}
+	c.JSON(http.StatusOK, mission)
+}
+
 // GetAllMissions godoc
 // @Summary Get all missions
-// @Description Retrieves a list of all missions
+// @Description Retrieves a page of missions ordered by ID, optionally filtered. When more missions follow, the X-Next-Cursor header holds the cursor for the next page and the Link header a link to it.
 // @Tags missions
-// @Produce json
+// @Produce json,application/problem+json
+// @Param completed query bool false "Only completed or only incomplete missions"
+// @Param cat_id query int false "Only missions assigned to the cat"
+// @Param unassigned query bool false "Only missions without an assigned cat"
+// @Param country query string false "Only missions with a target in the country, given by ISO 3166 code or name"
+// @Param has_incomplete_targets query bool false "Only missions with (or without) incomplete targets"
+// @Param cursor query int false "Cursor returned in X-Next-Cursor by the previous page"
+// @Param limit query int false "Page size (1-100)" default(50)
 // @Success 200 {array} model.Mission "List of missions"
-// @Failure 500 {object} map[string]interface{} "Failed to retrieve missions"
+// @Header 200 {integer} X-Next-Cursor "Cursor of the next page, absent on the last page"
+// @Header 200 {string} Link "Link to the next page"
+// @Failure 400 {object} model.Problem "Invalid query parameter"
+// @Failure 500 {object} model.Problem "Failed to retrieve missions"
 // @Router /mission [get]
 func (h *MissionHandler) GetAllMissions(c *gin.Context) {
-	missions, err := h.MissionRepo.GetAll()
+	filter, err := h.parseMissionFilter(c)
+	if err != nil {
+		badQuery(c, err)
+		return
+	}
+
+	missions, nextCursor, err := h.MissionRepo.GetAll(c.Request.Context(), filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve missions"})
+		respondError(c, err, "Failed to retrieve missions")
 		return
 	}
+
+	setCursorLink(c, nextCursor)
 	c.JSON(http.StatusOK, missions)
 }
 
+// normalizeCountry replaces the country of the target with its alpha-2 code.
+// Unknown countries are added to the field errors unless the field already failed validation.
+func (h *MissionHandler) normalizeCountry(target *model.Target, field string, fields []model.FieldError) []model.FieldError {
+	if slices.ContainsFunc(fields, func(f model.FieldError) bool { return f.Field == field }) {
+		return fields
+	}
+	code, err := h.Countries.Normalize(target.Country)
+	if err != nil {
+		return append(fields, model.FieldError{Field: field, Message: "is not a known country"})
+	}
+	target.Country = code
+	return fields
+}
+
+// parseMissionFilter reads the mission listing query parameters
+func (h *MissionHandler) parseMissionFilter(c *gin.Context) (model.MissionFilter, error) {
+	filter := model.MissionFilter{Country: c.Query("country")}
+	if country, ok := h.Countries.Lookup(filter.Country); ok {
+		filter.Country = country.Alpha2
+	}
+
+	var err error
+	if filter.Completed, err = queryBool(c, "completed"); err != nil {
+		return filter, err
+	}
+	if filter.CatID, err = queryInt(c, "cat_id"); err != nil {
+		return filter, err
+	}
+	unassigned, err := queryBool(c, "unassigned")
+	if err != nil {
+		return filter, err
+	}
+	filter.Unassigned = unassigned != nil && *unassigned
+	if filter.HasIncompleteTargets, err = queryBool(c, "has_incomplete_targets"); err != nil {
+		return filter, err
+	}
+	cursor, err := queryInt(c, "cursor")
+	if err != nil {
+		return filter, err
+	}
+	if cursor != nil {
+		filter.AfterID = *cursor
+	}
+	if filter.Limit, err = queryLimit(c); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
+
 // GetMissionByID godoc
 // @Summary Get a single mission by ID
 // @Description Retrieves a mission by its ID
 // @Tags missions
-// @Produce json
+// @Produce json,application/problem+json
 // @Param id path int true "Mission ID"
+// @Param If-None-Match header string false "ETag of a cached copy of the mission"
 // @Success 200 {object} model.Mission "Mission details"
-// @Failure 400 {object} map[string]interface{} "Invalid mission ID"
-// @Failure 404 {object} map[string]interface{} "Mission not found"
+// @Header 200 {string} ETag "Version of the mission"
+// @Success 304 "Cached copy is up to date"
+// @Failure 400 {object} model.Problem "Invalid mission ID"
+// @Failure 404 {object} model.Problem "Mission not found"
+// @Failure 500 {object} model.Problem "Failed to retrieve mission"
 // @Router /mission/{id} [get]
 func (h *MissionHandler) GetMissionByID(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid mission ID"})
+		invalidParam(c, "id", "must be an integer")
 		return
 	}
 
-	mission, err := h.MissionRepo.GetByID(id)
+	mission, err := h.MissionRepo.GetByID(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Mission not found"})
+		respondError(c, err, "Failed to retrieve mission")
+		return
+	}
+	if notModified(c, mission.Version) {
 		return
 	}
 
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"main/internal/model"
+	"main/internal/payroll"
+	"main/internal/repositories"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const monthLayout = "2006-01"
+
+type PayrollHandler struct {
+	CatRepo repositories.CatStore
+}
+
+func NewPayrollHandler(catRepo repositories.CatStore) *PayrollHandler {
+	return &PayrollHandler{CatRepo: catRepo}
+}
+
+// GetPayroll godoc
+// @Summary Get the payroll of a month
+// @Description Computes what each cat was owed for a month from the salary history. Salaries are monthly and paid per day, so a month with salary changes is split into periods, each paid salary * days / days in the month and rounded half up to the cent. Cats owed nothing are left out.
+// @Tags payroll
+// @Produce json,application/problem+json
+// @Param month query string false "Month as YYYY-MM, the current month by default" example(2024-05)
+// @Success 200 {array} model.PayrollEntry "What each cat was owed"
+// @Failure 400 {object} model.Problem "Invalid month"
+// @Failure 500 {object} model.Problem "Failed to compute payroll"
+// @Router /payroll [get]
+func (h *PayrollHandler) GetPayroll(c *gin.Context) {
+	today := model.Today()
+	from := today.AddDays(1 - today.Day())
+	if month := c.Query("month"); month != "" {
+		start, err := time.Parse(monthLayout, month)
+		if err != nil {
+			invalidParam(c, "month", "must be a month such as 2024-05")
+			return
+		}
+		from = model.NewDate(start)
+	}
+	to := from.AddMonths(1)
+
+	changes, err := h.CatRepo.GetSalaryChanges(c.Request.Context(), from, to)
+	if err != nil {
+		respondError(c, err, "Failed to compute payroll")
+		return
+	}
+
+	entries, err := payroll.Compute(from, changes)
+	if err != nil {
+		respondError(c, err, "Failed to compute payroll")
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
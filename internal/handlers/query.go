@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"main/internal/model"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 100
+)
+
+// paramError reports an invalid query parameter
+type paramError struct {
+	name string
+	msg  string
+}
+
+func (e *paramError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.name, e.msg)
+}
+
+// queryInt parses an optional integer query parameter
+func queryInt(c *gin.Context, name string) (*int, error) {
+	raw, ok := c.GetQuery(name)
+	if !ok || raw == "" {
+		return nil, nil
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		return nil, &paramError{name: name, msg: "must be an integer"}
+	}
+	return &value, nil
+}
+
+// queryMoney parses an optional amount query parameter
+func queryMoney(c *gin.Context, name string) (*model.Money, error) {
+	raw, ok := c.GetQuery(name)
+	if !ok || raw == "" {
+		return nil, nil
+	}
+	value, err := model.ParseMoney(raw)
+	if err != nil {
+		return nil, &paramError{name: name, msg: "must be an amount such as 2700.10"}
+	}
+	return &value, nil
+}
+
+// queryBool parses an optional boolean query parameter
+func queryBool(c *gin.Context, name string) (*bool, error) {
+	raw, ok := c.GetQuery(name)
+	if !ok || raw == "" {
+		return nil, nil
+	}
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		return nil, &paramError{name: name, msg: "must be true or false"}
+	}
+	return &value, nil
+}
+
+// queryTime parses an optional RFC 3339 time query parameter
+func queryTime(c *gin.Context, name string) (*time.Time, error) {
+	raw, ok := c.GetQuery(name)
+	if !ok || raw == "" {
+		return nil, nil
+	}
+	value, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, &paramError{name: name, msg: "must be an RFC 3339 time such as 2024-05-01T00:00:00Z"}
+	}
+	return &value, nil
+}
+
+// queryLimit parses the limit query parameter, applying the default and maximum page size
+func queryLimit(c *gin.Context) (int, error) {
+	limit, err := queryInt(c, "limit")
+	if err != nil {
+		return 0, err
+	}
+	if limit == nil {
+		return defaultPageLimit, nil
+	}
+	if *limit < 1 || *limit > maxPageLimit {
+		return 0, &paramError{name: "limit", msg: fmt.Sprintf("must be between 1 and %d", maxPageLimit)}
+	}
+	return *limit, nil
+}
+
+// setPageLinks sets the Link header with the next and previous pages of an offset paginated listing
+func setPageLinks(c *gin.Context, limit, offset, total int) {
+	link := func(offset int, rel string) string {
+		query := url.Values{}
+		for key, values := range c.Request.URL.Query() {
+			query[key] = values
+		}
+		query.Set("limit", strconv.Itoa(limit))
+		query.Set("offset", strconv.Itoa(offset))
+		return fmt.Sprintf(`<%s?%s>; rel="%s"`, c.Request.URL.Path, query.Encode(), rel)
+	}
+
+	var links []string
+	if offset+limit < total {
+		links = append(links, link(offset+limit, "next"))
+	}
+	if offset > 0 {
+		links = append(links, link(max(offset-limit, 0), "prev"))
+	}
+	for _, l := range links {
+		c.Writer.Header().Add("Link", l)
+	}
+	c.Header("X-Total-Count", strconv.Itoa(total))
+}
+
+// setCursorLink sets the X-Next-Cursor and Link headers of a keyset paginated listing
+func setCursorLink(c *gin.Context, nextCursor int) {
+	if nextCursor == 0 {
+		return
+	}
+	query := url.Values{}
+	for key, values := range c.Request.URL.Query() {
+		query[key] = values
+	}
+	query.Set("cursor", strconv.Itoa(nextCursor))
+	c.Header("X-Next-Cursor", strconv.Itoa(nextCursor))
+	c.Header("Link", fmt.Sprintf(`<%s?%s>; rel="next"`, c.Request.URL.Path, query.Encode()))
+}
+
+// badQuery responds with 400 for an invalid query parameter
+func badQuery(c *gin.Context, err error) {
+	var paramErr *paramError
+	if errors.As(err, &paramErr) {
+		invalidParam(c, paramErr.name, paramErr.msg)
+		return
+	}
+	respondError(c, err, "Invalid query parameter")
+}
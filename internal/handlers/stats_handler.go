@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"main/internal/model"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PoolStatter reports the state of a database connection pool
+type PoolStatter interface {
+	PoolStats() model.PoolStats
+}
+
+type StatsHandler struct {
+	Pool PoolStatter
+}
+
+func NewStatsHandler(pool PoolStatter) *StatsHandler {
+	return &StatsHandler{Pool: pool}
+}
+
+// GetPoolStats godoc
+// @Summary Get database pool statistics
+// @Description Reports the open, busy and idle connections of the Postgres connection pool and how long requests waited for one. Only available with Postgres storage.
+// @Tags monitoring
+// @Produce json
+// @Success 200 {object} model.PoolStats "Connection pool statistics"
+// @Router /stats/db [get]
+func (h *StatsHandler) GetPoolStats(c *gin.Context) {
+	c.JSON(http.StatusOK, h.Pool.PoolStats())
+}
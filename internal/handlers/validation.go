@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"main/internal/model"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/go-playground/validator/v10/non-standard/validators"
+)
+
+func init() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	// Report fields by their JSON names
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	if err := v.RegisterValidation("notblank", validators.NotBlank); err != nil {
+		panic(err)
+	}
+	// Compare amounts by their cents, so that gte=0 rejects negative amounts
+	v.RegisterCustomTypeFunc(func(field reflect.Value) any {
+		return field.Interface().(model.Money).Cents()
+	}, model.Money{})
+}
+
+// bindJSON decodes and validates the request body. It responds and returns
+// false when the body is malformed or breaks the validation rules.
+func bindJSON(c *gin.Context, request any) bool {
+	fields, ok := decodeJSON(c, request)
+	if !ok {
+		return false
+	}
+	if len(fields) > 0 {
+		invalidFields(c, fields...)
+		return false
+	}
+	return true
+}
+
+// decodeJSON decodes and validates the request body. It responds and returns
+// false only when the body is malformed; validation failures are returned so
+// the handler can add its own checks and report all of them together.
+func decodeJSON(c *gin.Context, request any) ([]model.FieldError, bool) {
+	err := c.ShouldBindJSON(request)
+	if err == nil {
+		return nil, true
+	}
+
+	fields, ok := validationFields(err)
+	if !ok {
+		invalidBody(c, err)
+		return nil, false
+	}
+	return fields, true
+}
+
+// validate checks a decoded request against its validation rules
+func validate(request any) []model.FieldError {
+	fields, _ := validationFields(binding.Validator.ValidateStruct(request))
+	return fields
+}
+
+// validationFields converts validation failures to field errors.
+// It reports false if err is not a validation failure.
+func validationFields(err error) ([]model.FieldError, bool) {
+	if err == nil {
+		return nil, true
+	}
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return nil, false
+	}
+
+	fields := make([]model.FieldError, len(validationErrs))
+	for i, fieldErr := range validationErrs {
+		fields[i] = model.FieldError{Field: fieldPath(fieldErr), Message: validationMessage(fieldErr)}
+	}
+	return fields, true
+}
+
+// fieldPath returns the JSON path of the field without the name of the request type, e.g. targets[0].name
+func fieldPath(fieldErr validator.FieldError) string {
+	namespace := fieldErr.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return namespace
+}
+
+// validationMessage describes a failed validation rule
+func validationMessage(fieldErr validator.FieldError) string {
+	param := fieldErr.Param()
+	isString := fieldErr.Kind() == reflect.String
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "notblank":
+		return "must not be blank"
+	case "max":
+		if isString {
+			return fmt.Sprintf("must be at most %s characters long", param)
+		}
+		return "must be at most " + param
+	case "min":
+		if isString {
+			return fmt.Sprintf("must be at least %s characters long", param)
+		}
+		return "must be at least " + param
+	case "lte":
+		return "must be at most " + param
+	case "gte":
+		return "must be at least " + param
+	case "gt":
+		return "must be greater than " + param
+	case "lt":
+		return "must be less than " + param
+	case "datetime":
+		return "must be a date such as " + param
+	case "oneof":
+		return "must be one of " + strings.ReplaceAll(param, " ", ", ")
+	}
+	return "is invalid"
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditEntity names the kind of entity an audit event is about
+type AuditEntity string
+
+const (
+	AuditCat     AuditEntity = "cat"
+	AuditMission AuditEntity = "mission"
+	AuditTarget  AuditEntity = "target"
+)
+
+// Valid reports whether the entity is one of the audited entities
+func (e AuditEntity) Valid() bool {
+	switch e {
+	case AuditCat, AuditMission, AuditTarget:
+		return true
+	}
+	return false
+}
+
+// AuditEvent records a change made to a cat, mission or target
+type AuditEvent struct {
+	ID         int         `json:"id"`
+	Actor      string      `json:"actor" example:"alice"`
+	EntityType AuditEntity `json:"entity_type" example:"cat"`
+	EntityID   int         `json:"entity_id" example:"7"`
+	Action     string      `json:"action" example:"update_salary"`
+	// Before is the entity before the change, absent for creations
+	Before json.RawMessage `json:"before,omitempty" swaggertype:"object"`
+	// After is the entity after the change, absent for deletions
+	After      json.RawMessage `json:"after,omitempty" swaggertype:"object"`
+	RequestID  string          `json:"request_id,omitempty"`
+	OccurredAt time.Time       `json:"occurred_at"`
+}
+
+// AuditFilter narrows an audit listing, which is ordered by ID and paged by keyset
+type AuditFilter struct {
+	EntityType AuditEntity
+	EntityID   *int
+	Actor      string
+	// From and To bound the time of the change; From is inclusive and To exclusive
+	From *time.Time
+	To   *time.Time
+	// AfterID is the keyset cursor: only events with a greater ID are returned
+	AfterID int
+	Limit   int
+}
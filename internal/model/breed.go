@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+type Breed struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Origin      string   `json:"origin"`
+	Temperament string   `json:"temperament"`
+	AltNames    []string `json:"alt_names"`
+}
+
+// BreedSync records when the breed list was last fetched from its source
+type BreedSync struct {
+	ETag      string
+	FetchedAt time.Time
+}
@@ -1,13 +1,51 @@
 package model
 
 type SpyCat struct {
-	ID                int     `json:"id"`
-	Name              string  `json:"name"`
-	ExperienceInYears int     `json:"experience_in_years"`
-	Breed             string  `json:"breed"`
-	Salary            float64 `json:"salary"`
+	ID                int    `json:"id"`
+	Name              string `json:"name"`
+	ExperienceInYears int    `json:"experience_in_years"`
+	Breed             string `json:"breed"`
+	Salary            Money  `json:"salary" swaggertype:"number" example:"2700.10"`
+	// Version is incremented on every change and sent as the ETag of the cat
+	Version int `json:"version"`
+}
+
+// CreateCatRequest holds the fields a client may set when creating or replacing a cat
+type CreateCatRequest struct {
+	Name              string `json:"name" binding:"required,notblank,max=255"`
+	ExperienceInYears *int   `json:"experience_in_years" binding:"required,gte=0,lte=100"`
+	Breed             string `json:"breed" binding:"required,notblank,max=255"`
+	Salary            *Money `json:"salary" binding:"required,gte=0" swaggertype:"number" example:"2700.10"`
+}
+
+// Cat returns the cat described by the request
+func (r CreateCatRequest) Cat() SpyCat {
+	return SpyCat{
+		Name:              r.Name,
+		ExperienceInYears: *r.ExperienceInYears,
+		Breed:             r.Breed,
+		Salary:            *r.Salary,
+	}
 }
 
 type SalaryUpdate struct {
-	Salary float64 `json:"salary"`
+	Salary *Money `json:"salary" binding:"required,gte=0" swaggertype:"number" example:"2700.10"`
+	Reason string `json:"reason" binding:"max=1000"`
+	// EffectiveFrom is the first day paid at the new salary, today by default.
+	// It may be backdated, but neither into the future nor before the previous change.
+	EffectiveFrom string `json:"effective_from" binding:"omitempty,datetime=2006-01-02" format:"date" example:"2024-05-01"`
+}
+
+// CatFilter narrows, orders and pages a cat listing
+type CatFilter struct {
+	Name          string
+	Breed         string
+	MinExperience *int
+	MaxExperience *int
+	MinSalary     *Money
+	MaxSalary     *Money
+	// Sort is a comma separated list of fields, each optionally prefixed with "-" for descending order
+	Sort   string
+	Limit  int
+	Offset int
 }
@@ -0,0 +1,9 @@
+package model
+
+// Country is an ISO 3166-1 country. Targets store countries by their alpha-2 code.
+type Country struct {
+	Alpha2   string   `json:"alpha2" example:"UA"`
+	Alpha3   string   `json:"alpha3" example:"UKR"`
+	Name     string   `json:"name" example:"Ukraine"`
+	AltNames []string `json:"alt_names"`
+}
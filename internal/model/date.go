@@ -0,0 +1,106 @@
+package model
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// Date is a calendar day, written as YYYY-MM-DD in JSON and stored as a Postgres DATE.
+// The zero Date is January 1 of year 1.
+type Date struct {
+	t time.Time
+}
+
+// NewDate returns the day of t, ignoring its time and location
+func NewDate(t time.Time) Date {
+	year, month, day := t.Date()
+	return Date{time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// Today returns the current day in UTC
+func Today() Date {
+	return NewDate(time.Now().UTC())
+}
+
+// ParseDate parses a YYYY-MM-DD date
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return Date{}, err
+	}
+	return Date{t}, nil
+}
+
+// Time returns midnight UTC of the day
+func (d Date) Time() time.Time {
+	return d.t
+}
+
+// Day returns the day of the month
+func (d Date) Day() int {
+	return d.t.Day()
+}
+
+// AddDays returns the date n days later
+func (d Date) AddDays(n int) Date {
+	return Date{d.t.AddDate(0, 0, n)}
+}
+
+// AddMonths returns the date n months later, normalized like time.Time.AddDate
+func (d Date) AddMonths(n int) Date {
+	return Date{d.t.AddDate(0, n, 0)}
+}
+
+// DaysUntil returns the number of days from d to end
+func (d Date) DaysUntil(end Date) int {
+	return int(end.t.Sub(d.t).Hours() / 24)
+}
+
+func (d Date) Before(other Date) bool {
+	return d.t.Before(other.t)
+}
+
+func (d Date) After(other Date) bool {
+	return d.t.After(other.t)
+}
+
+func (d Date) IsZero() bool {
+	return d.t.IsZero()
+}
+
+func (d Date) String() string {
+	return d.t.Format(dateLayout)
+}
+
+// MarshalText writes the date as YYYY-MM-DD, which JSON quotes as a string
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText reads a YYYY-MM-DD date
+func (d *Date) UnmarshalText(text []byte) error {
+	parsed, err := ParseDate(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// Scan reads a Postgres DATE
+func (d *Date) Scan(src any) error {
+	t, ok := src.(time.Time)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into a date", src)
+	}
+	*d = NewDate(t)
+	return nil
+}
+
+// Value writes the date as YYYY-MM-DD
+func (d Date) Value() (driver.Value, error) {
+	return d.String(), nil
+}
@@ -1,8 +1,120 @@
 package model
 
+import "time"
+
+type MissionStatus string
+
+const (
+	MissionDraft      MissionStatus = "draft"
+	MissionAssigned   MissionStatus = "assigned"
+	MissionInProgress MissionStatus = "in_progress"
+	MissionCompleted  MissionStatus = "completed"
+	MissionAborted    MissionStatus = "aborted"
+)
+
+// Valid reports whether the status is one of the known mission statuses
+func (s MissionStatus) Valid() bool {
+	switch s {
+	case MissionDraft, MissionAssigned, MissionInProgress, MissionCompleted, MissionAborted:
+		return true
+	}
+	return false
+}
+
+// Closed reports whether the mission can no longer be worked on
+func (s MissionStatus) Closed() bool {
+	return s == MissionCompleted || s == MissionAborted
+}
+
 type Mission struct {
-	ID        int      `json:"id"`
-	CatID     int      `json:"cat_id"`
-	Completed bool     `json:"completed"`
-	Targets   []Target `json:"targets"`
+	ID     int           `json:"id"`
+	CatID  int           `json:"cat_id"`
+	Status MissionStatus `json:"status"`
+	// Completed mirrors Status == "completed" for clients of the earlier API
+	Completed bool                `json:"completed"`
+	Targets   []Target            `json:"targets"`
+	History   []MissionTransition `json:"history,omitempty"`
+	// Version is incremented on every change to the mission or its targets and sent as its ETag
+	Version int `json:"version"`
+}
+
+// CreateMissionRequest holds the fields a client may set when creating a mission
+type CreateMissionRequest struct {
+	// CatID optionally assigns a cat right away
+	CatID   int                   `json:"cat_id" binding:"gte=0"`
+	Targets []CreateTargetRequest `json:"targets" binding:"dive"`
+}
+
+// Mission returns the mission described by the request
+func (r CreateMissionRequest) Mission() Mission {
+	mission := Mission{CatID: r.CatID, Targets: make([]Target, len(r.Targets))}
+	for i, target := range r.Targets {
+		mission.Targets[i] = target.Target()
+	}
+	return mission
+}
+
+// MissionTransition records a change of a mission's status
+type MissionTransition struct {
+	From   MissionStatus `json:"from,omitempty"`
+	To     MissionStatus `json:"to"`
+	Reason string        `json:"reason,omitempty"`
+	At     time.Time     `json:"at"`
+}
+
+// MissionTransitionRequest asks to move a mission to another status
+type MissionTransitionRequest struct {
+	Status MissionStatus `json:"status"`
+	Reason string        `json:"reason"`
+}
+
+// CompleteMissionRequest optionally forces completion of a mission that still has open targets
+type CompleteMissionRequest struct {
+	Force  bool   `json:"force"`
+	Reason string `json:"reason"`
+}
+
+// MissionFilter narrows a mission listing, which is ordered by ID and paged by keyset
+type MissionFilter struct {
+	Completed  *bool
+	CatID      *int
+	Unassigned bool
+	// Country matches missions having at least one target in the country
+	Country              string
+	HasIncompleteTargets *bool
+	// AfterID is the keyset cursor: only missions with a greater ID are returned
+	AfterID int
+	Limit   int
+}
+
+// MissionAssignment records the period a cat was assigned to a mission
+type MissionAssignment struct {
+	MissionID    int        `json:"mission_id"`
+	CatID        int        `json:"cat_id"`
+	AssignedAt   time.Time  `json:"assigned_at"`
+	UnassignedAt *time.Time `json:"unassigned_at,omitempty"`
+	// Reason explains why the cat was unassigned or replaced
+	Reason string `json:"reason,omitempty"`
+}
+
+// AssignCatRequest assigns a cat to a draft mission
+type AssignCatRequest struct {
+	CatID int `json:"cat_id" binding:"required,gt=0"`
+}
+
+// UnassignCatRequest optionally explains why the cat is removed from a mission
+type UnassignCatRequest struct {
+	Reason string `json:"reason"`
+}
+
+// ReassignCatRequest replaces the cat of a mission
+type ReassignCatRequest struct {
+	CatID  int    `json:"cat_id" binding:"required,gt=0"`
+	Reason string `json:"reason" binding:"required,notblank"`
+}
+
+// AssignmentFilter narrows an assignment listing, which is ordered by assignment time
+type AssignmentFilter struct {
+	MissionID *int
+	CatID     *int
 }
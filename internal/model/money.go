@@ -0,0 +1,135 @@
+package model
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is the ISO 4217 code of the currency salaries are paid in
+const DefaultCurrency = "USD"
+
+var decimalPattern = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+// maxCents is the largest amount a DECIMAL(10, 2) column holds, in cents
+const maxCents = 99999999_99
+
+// ErrInvalidMoney is returned for amounts that are malformed, have more than
+// two decimals or do not fit a DECIMAL(10, 2) column
+var ErrInvalidMoney = errors.New("invalid amount")
+
+// Money is an exact amount of DefaultCurrency with two decimals. It is written
+// to JSON as a number with two decimals and stored as a Postgres NUMERIC.
+// Responses that need the currency carry DefaultCurrency next to the amount.
+type Money struct {
+	cents int64
+}
+
+// NewMoney returns the amount of cents
+func NewMoney(cents int64) (Money, error) {
+	if cents > maxCents || cents < -maxCents {
+		return Money{}, fmt.Errorf("%w: %s is out of range", ErrInvalidMoney, formatCents(cents))
+	}
+	return Money{cents: cents}, nil
+}
+
+// ParseMoney parses a decimal amount such as 2700.1
+func ParseMoney(s string) (Money, error) {
+	if !decimalPattern.MatchString(s) {
+		return Money{}, fmt.Errorf("%w: %q is not a decimal number", ErrInvalidMoney, s)
+	}
+	units, fraction, _ := strings.Cut(s, ".")
+	fraction = strings.TrimRight(fraction, "0")
+	if len(fraction) > 2 {
+		return Money{}, fmt.Errorf("%w: %s has more than two decimals", ErrInvalidMoney, s)
+	}
+	cents, err := strconv.ParseInt(units+fraction+strings.Repeat("0", 2-len(fraction)), 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %s is out of range", ErrInvalidMoney, s)
+	}
+	return NewMoney(cents)
+}
+
+// Cents returns the amount in hundredths of the currency unit
+func (m Money) Cents() int64 {
+	return m.cents
+}
+
+// Cmp compares two amounts, returning -1, 0 or +1
+func (m Money) Cmp(other Money) int {
+	switch {
+	case m.cents < other.cents:
+		return -1
+	case m.cents > other.cents:
+		return 1
+	}
+	return 0
+}
+
+// String returns the amount with two decimals, e.g. 2700.10
+func (m Money) String() string {
+	return formatCents(m.cents)
+}
+
+func formatCents(cents int64) string {
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON reads a number or a string holding one, rejecting invalid amounts
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	s := string(data)
+	if data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	}
+	parsed, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// Scan reads a Postgres NUMERIC exactly
+func (m *Money) Scan(src any) error {
+	var s string
+	switch v := src.(type) {
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	case int64:
+		s = strconv.FormatInt(v, 10)
+	default:
+		return fmt.Errorf("cannot scan %T into an amount", src)
+	}
+	parsed, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// Value writes the amount as a decimal string, which Postgres casts to NUMERIC exactly
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
@@ -0,0 +1,170 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		in    string
+		cents int64
+		err   bool
+	}{
+		{in: "2700.10", cents: 270010},
+		{in: "2700.1", cents: 270010},
+		{in: "2700.100", cents: 270010},
+		{in: "2700", cents: 270000},
+		{in: "0.01", cents: 1},
+		{in: "-0.5", cents: -50},
+		{in: "-0", cents: 0},
+		{in: "1.234", err: true},
+		{in: "1.2340", err: true},
+		{in: "0.001", err: true},
+		{in: "1e3", err: true},
+		{in: "1E-2", err: true},
+		{in: "2.7001e3", err: true},
+		{in: "99999999.99", cents: 9999999999},
+		{in: "-99999999.99", cents: -9999999999},
+		{in: "100000000.00", err: true},
+		{in: "-100000000", err: true},
+		{in: "92233720368547758.07", err: true},
+		{in: "9223372036854775808", err: true},
+		{in: "-9223372036854775809", err: true},
+		{in: "", err: true},
+		{in: ".5", err: true},
+		{in: "5.", err: true},
+		{in: "+5", err: true},
+		{in: "1,5", err: true},
+		{in: " 5", err: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseMoney(tt.in)
+		if tt.err {
+			if !errors.Is(err, ErrInvalidMoney) {
+				t.Errorf("ParseMoney(%q) = %s, %v; want ErrInvalidMoney", tt.in, got, err)
+			}
+			continue
+		}
+		if err != nil || got.Cents() != tt.cents {
+			t.Errorf("ParseMoney(%q) = %d cents, %v; want %d cents", tt.in, got.Cents(), err, tt.cents)
+		}
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	tests := []struct {
+		cents int64
+		want  string
+	}{
+		{cents: 0, want: "0.00"},
+		{cents: 1, want: "0.01"},
+		{cents: -50, want: "-0.50"},
+		{cents: 270010, want: "2700.10"},
+		{cents: 9999999999, want: "99999999.99"},
+	}
+	for _, tt := range tests {
+		m, err := NewMoney(tt.cents)
+		if err != nil {
+			t.Fatalf("NewMoney(%d): %v", tt.cents, err)
+		}
+		if got := m.String(); got != tt.want {
+			t.Errorf("NewMoney(%d).String() = %q, want %q", tt.cents, got, tt.want)
+		}
+		data, err := json.Marshal(m)
+		if err != nil || string(data) != tt.want {
+			t.Errorf("json.Marshal(%s) = %s, %v; want %s", m, data, err, tt.want)
+		}
+	}
+}
+
+func TestMoneyUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		in    string
+		cents int64
+		err   bool
+	}{
+		{in: `2700.10`, cents: 270010},
+		{in: `"2700.10"`, cents: 270010},
+		{in: `2700.1`, cents: 270010},
+		{in: `"2700.1"`, cents: 270010},
+		{in: `2700.100`, cents: 270010},
+		{in: `-0.5`, cents: -50},
+		{in: `"-0.5"`, cents: -50},
+		{in: `99999999.99`, cents: 9999999999},
+		{in: `"99999999.99"`, cents: 9999999999},
+		{in: `100000000.00`, err: true},
+		{in: `"100000000.00"`, err: true},
+		{in: `9223372036854775808`, err: true},
+		{in: `"9223372036854775808"`, err: true},
+		{in: `1.234`, err: true},
+		{in: `"1.234"`, err: true},
+		{in: `1e3`, err: true},
+		{in: `"1e3"`, err: true},
+		{in: `2.7001E3`, err: true},
+		{in: `""`, err: true},
+		{in: `"abc"`, err: true},
+		{in: `true`, err: true},
+	}
+	for _, tt := range tests {
+		var got Money
+		err := json.Unmarshal([]byte(tt.in), &got)
+		if tt.err {
+			if err == nil {
+				t.Errorf("Unmarshal(%s) = %s, want an error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil || got.Cents() != tt.cents {
+			t.Errorf("Unmarshal(%s) = %d cents, %v; want %d cents", tt.in, got.Cents(), err, tt.cents)
+		}
+	}
+}
+
+func TestMoneyUnmarshalNull(t *testing.T) {
+	var body struct {
+		Salary *Money `json:"salary"`
+	}
+	if err := json.Unmarshal([]byte(`{"salary": null}`), &body); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if body.Salary != nil {
+		t.Fatalf("null salary unmarshaled to %s, want nil", body.Salary)
+	}
+}
+
+func TestMoneyScan(t *testing.T) {
+	tests := []struct {
+		src   any
+		cents int64
+		err   bool
+	}{
+		{src: []byte("2700.10"), cents: 270010},
+		{src: "2700.10", cents: 270010},
+		{src: []byte("2700.1"), cents: 270010},
+		{src: []byte("-0.50"), cents: -50},
+		{src: int64(2700), cents: 270000},
+		{src: []byte("99999999.99"), cents: 9999999999},
+		{src: []byte("100000000.00"), err: true},
+		{src: []byte("9223372036854775808"), err: true},
+		{src: int64(9223372036854775807), err: true},
+		{src: []byte("1.234"), err: true},
+		{src: "1e3", err: true},
+		{src: 2700.1, err: true},
+		{src: nil, err: true},
+	}
+	for _, tt := range tests {
+		var got Money
+		err := got.Scan(tt.src)
+		if tt.err {
+			if err == nil {
+				t.Errorf("Scan(%#v) = %s, want an error", tt.src, got)
+			}
+			continue
+		}
+		if err != nil || got.Cents() != tt.cents {
+			t.Errorf("Scan(%#v) = %d cents, %v; want %d cents", tt.src, got.Cents(), err, tt.cents)
+		}
+	}
+}
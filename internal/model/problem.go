@@ -0,0 +1,22 @@
+package model
+
+// Problem describes an error response as RFC 7807 problem details,
+// sent with the application/problem+json content type
+type Problem struct {
+	// Type is a URI identifying the kind of problem
+	Type   string `json:"type" example:"urn:spy-cats:problem:cat_not_found"`
+	Title  string `json:"title" example:"Cat not found"`
+	Status int    `json:"status" example:"404"`
+	Detail string `json:"detail,omitempty" example:"cat not found: id 7"`
+	// Instance is the path of the request that caused the problem
+	Instance string `json:"instance,omitempty" example:"/cat/7"`
+	// Code is the stable, machine-readable identifier of the problem
+	Code   string       `json:"code" example:"cat_not_found"`
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError describes a problem with a single request field or parameter
+type FieldError struct {
+	Field   string `json:"field" example:"limit"`
+	Message string `json:"message" example:"must be between 1 and 100"`
+}
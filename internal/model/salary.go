@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+// SalaryChange records a new monthly salary of a cat and the day it takes effect
+type SalaryChange struct {
+	ID            int    `json:"id"`
+	CatID         int    `json:"cat_id"`
+	Salary        Money  `json:"salary" swaggertype:"number" example:"2700.10"`
+	EffectiveFrom Date   `json:"effective_from" swaggertype:"string" format:"date" example:"2024-05-01"`
+	Reason        string `json:"reason,omitempty"`
+	// ApprovedBy is the actor who made the change
+	ApprovedBy string    `json:"approved_by"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// PayrollEntry is what a cat was owed for a month
+type PayrollEntry struct {
+	CatID  int    `json:"cat_id"`
+	Month  string `json:"month" example:"2024-05"`
+	Amount Money  `json:"amount" swaggertype:"number" example:"1306.50"`
+	// Currency is the ISO 4217 code of the amounts
+	Currency string `json:"currency" example:"USD"`
+	// Periods splits the month by the salary in effect, in order
+	Periods []PayrollPeriod `json:"periods"`
+}
+
+// PayrollPeriod is a run of days in a month paid at the same salary
+type PayrollPeriod struct {
+	From   Date  `json:"from" swaggertype:"string" format:"date" example:"2024-05-01"`
+	To     Date  `json:"to" swaggertype:"string" format:"date" example:"2024-05-14"`
+	Days   int   `json:"days"`
+	Salary Money `json:"salary" swaggertype:"number" example:"2700.10"`
+	Amount Money `json:"amount" swaggertype:"number" example:"1306.50"`
+}
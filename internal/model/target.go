@@ -6,8 +6,22 @@ type Target struct {
 	Country  string `json:"country"`
 	Notes    string `json:"notes"`
 	Complete bool   `json:"complete"`
+	// Version is incremented on every change and is matched by If-Match on target requests
+	Version int `json:"version"`
+}
+
+// CreateTargetRequest holds the fields a client may set when creating a target
+type CreateTargetRequest struct {
+	Name    string `json:"name" binding:"required,notblank,max=255"`
+	Country string `json:"country" binding:"required,notblank,max=255"`
+	Notes   string `json:"notes"`
+}
+
+// Target returns the incomplete target described by the request
+func (r CreateTargetRequest) Target() Target {
+	return Target{Name: r.Name, Country: r.Country, Notes: r.Notes}
 }
 
 type NoteUpdate struct {
-	Notes string `json:"notes"`
+	Notes *string `json:"notes" binding:"required"`
 }
@@ -0,0 +1,90 @@
+// Package payroll computes what the cats are owed from their salary history.
+//
+// Salaries are monthly and paid per day: a month is split into periods by the
+// salary in effect, and each period is paid salary * days / days in the month,
+// rounded half up to the cent. Amounts are computed in whole cents, so the
+// result is exact and the periods add up to the total.
+package payroll
+
+import (
+	"main/internal/model"
+)
+
+// Compute returns what each cat was owed for the month starting on start.
+// For every cat, changes must hold the last salary change before the month
+// and all changes within it, ordered by cat, effective date and ID. Cats
+// owed nothing are left out.
+func Compute(start model.Date, changes []model.SalaryChange) ([]model.PayrollEntry, error) {
+	end := start.AddMonths(1)
+	daysInMonth := start.DaysUntil(end)
+
+	entries := []model.PayrollEntry{}
+	for i := 0; i < len(changes); {
+		j := i
+		for j < len(changes) && changes[j].CatID == changes[i].CatID {
+			j++
+		}
+		entry, err := computeEntry(start, end, daysInMonth, changes[i:j])
+		if err != nil {
+			return nil, err
+		}
+		if len(entry.Periods) > 0 {
+			entries = append(entries, entry)
+		}
+		i = j
+	}
+	return entries, nil
+}
+
+// computeEntry computes the pay of a single cat
+func computeEntry(start, end model.Date, daysInMonth int, changes []model.SalaryChange) (model.PayrollEntry, error) {
+	entry := model.PayrollEntry{
+		CatID:    changes[0].CatID,
+		Month:    start.Time().Format("2006-01"),
+		Currency: model.DefaultCurrency,
+		Periods:  []model.PayrollPeriod{},
+	}
+	var total int64
+	for i, change := range changes {
+		from := latest(change.EffectiveFrom, start)
+		to := end
+		if i+1 < len(changes) {
+			to = latest(changes[i+1].EffectiveFrom, start)
+		}
+		// Later changes on the same day replace earlier ones
+		days := from.DaysUntil(to)
+		cents := change.Salary.Cents()
+		if days <= 0 || cents == 0 {
+			continue
+		}
+
+		amount, err := model.NewMoney(prorate(cents, days, daysInMonth))
+		if err != nil {
+			return entry, err
+		}
+		total += amount.Cents()
+		entry.Periods = append(entry.Periods, model.PayrollPeriod{
+			From:   from,
+			To:     to.AddDays(-1),
+			Days:   days,
+			Salary: change.Salary,
+			Amount: amount,
+		})
+	}
+
+	var err error
+	entry.Amount, err = model.NewMoney(total)
+	return entry, err
+}
+
+// prorate returns cents * days / daysInMonth rounded half up
+func prorate(cents int64, days, daysInMonth int) int64 {
+	return (2*cents*int64(days) + int64(daysInMonth)) / (2 * int64(daysInMonth))
+}
+
+func latest(a, b model.Date) model.Date {
+	if a.Before(b) {
+		return b
+	}
+	return a
+}
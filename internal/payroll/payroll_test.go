@@ -0,0 +1,213 @@
+package payroll
+
+import (
+	"main/internal/model"
+	"testing"
+)
+
+func date(t *testing.T, s string) model.Date {
+	t.Helper()
+	d, err := model.ParseDate(s)
+	if err != nil {
+		t.Fatalf("ParseDate(%q): %v", s, err)
+	}
+	return d
+}
+
+func money(t *testing.T, s string) model.Money {
+	t.Helper()
+	m, err := model.ParseMoney(s)
+	if err != nil {
+		t.Fatalf("ParseMoney(%q): %v", s, err)
+	}
+	return m
+}
+
+// change describes a salary change as catID, effective date and salary
+type change struct {
+	catID     int
+	effective string
+	salary    string
+}
+
+type period struct {
+	from, to string
+	days     int
+	amount   string
+}
+
+type entry struct {
+	catID   int
+	amount  string
+	periods []period
+}
+
+func TestCompute(t *testing.T) {
+	tests := []struct {
+		name    string
+		month   string
+		changes []change
+		want    []entry
+	}{
+		{
+			name:    "salary set before the month is paid in full",
+			month:   "2024-01-01",
+			changes: []change{{1, "2023-06-01", "1000"}},
+			want:    []entry{{1, "1000.00", []period{{"2024-01-01", "2024-01-31", 31, "1000.00"}}}},
+		},
+		{
+			name:    "mid-month change splits the month",
+			month:   "2024-01-01",
+			changes: []change{{1, "2023-06-01", "1000"}, {1, "2024-01-16", "2000"}},
+			want: []entry{{1, "1516.13", []period{
+				{"2024-01-01", "2024-01-15", 15, "483.87"},
+				{"2024-01-16", "2024-01-31", 16, "1032.26"},
+			}}},
+		},
+		{
+			name:    "last of several changes on the same day wins",
+			month:   "2024-01-01",
+			changes: []change{{1, "2023-06-01", "1000"}, {1, "2024-01-10", "1500"}, {1, "2024-01-10", "1800"}},
+			want: []entry{{1, "1567.74", []period{
+				{"2024-01-01", "2024-01-09", 9, "290.32"},
+				{"2024-01-10", "2024-01-31", 22, "1277.42"},
+			}}},
+		},
+		{
+			name:    "cat hired mid-month is paid from its first day",
+			month:   "2024-04-01",
+			changes: []change{{1, "2024-04-21", "3000"}},
+			want:    []entry{{1, "1000.00", []period{{"2024-04-21", "2024-04-30", 10, "1000.00"}}}},
+		},
+		{
+			name:    "cat deleted mid-month is paid until the day before",
+			month:   "2023-02-01",
+			changes: []change{{1, "2022-01-01", "1000"}, {1, "2023-02-15", "0"}},
+			want:    []entry{{1, "500.00", []period{{"2023-02-01", "2023-02-14", 14, "500.00"}}}},
+		},
+		{
+			name:    "cat deleted before the month is left out",
+			month:   "2024-01-01",
+			changes: []change{{1, "2023-06-01", "1000"}, {1, "2023-12-20", "0"}},
+			want:    nil,
+		},
+		{
+			name:    "28-day month",
+			month:   "2023-02-01",
+			changes: []change{{1, "2022-01-01", "1000"}, {1, "2023-02-02", "0"}},
+			want:    []entry{{1, "35.71", []period{{"2023-02-01", "2023-02-01", 1, "35.71"}}}},
+		},
+		{
+			name:    "29-day month",
+			month:   "2024-02-01",
+			changes: []change{{1, "2022-01-01", "1000"}, {1, "2024-02-02", "0"}},
+			want:    []entry{{1, "34.48", []period{{"2024-02-01", "2024-02-01", 1, "34.48"}}}},
+		},
+		{
+			name:    "30-day month",
+			month:   "2024-04-01",
+			changes: []change{{1, "2022-01-01", "1000"}, {1, "2024-04-02", "0"}},
+			want:    []entry{{1, "33.33", []period{{"2024-04-01", "2024-04-01", 1, "33.33"}}}},
+		},
+		{
+			name:    "31-day month",
+			month:   "2024-01-01",
+			changes: []change{{1, "2022-01-01", "1000"}, {1, "2024-01-02", "0"}},
+			want:    []entry{{1, "32.26", []period{{"2024-01-01", "2024-01-01", 1, "32.26"}}}},
+		},
+		{
+			name:    "half a cent is rounded up",
+			month:   "2024-04-01",
+			changes: []change{{1, "2022-01-01", "100.01"}, {1, "2024-04-16", "0"}},
+			want:    []entry{{1, "50.01", []period{{"2024-04-01", "2024-04-15", 15, "50.01"}}}},
+		},
+		{
+			name:    "periods are rounded separately and add up to the total",
+			month:   "2024-04-01",
+			changes: []change{{1, "2022-01-01", "0.03"}, {1, "2024-04-16", "0.05"}},
+			want: []entry{{1, "0.05", []period{
+				{"2024-04-01", "2024-04-15", 15, "0.02"},
+				{"2024-04-16", "2024-04-30", 15, "0.03"},
+			}}},
+		},
+		{
+			name:    "cats are paid separately",
+			month:   "2024-04-01",
+			changes: []change{{1, "2022-01-01", "1000"}, {2, "2022-01-01", "0"}, {3, "2024-04-16", "600"}},
+			want: []entry{
+				{1, "1000.00", []period{{"2024-04-01", "2024-04-30", 30, "1000.00"}}},
+				{3, "300.00", []period{{"2024-04-16", "2024-04-30", 15, "300.00"}}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes := make([]model.SalaryChange, len(tt.changes))
+			for i, c := range tt.changes {
+				changes[i] = model.SalaryChange{
+					ID:            i + 1,
+					CatID:         c.catID,
+					Salary:        money(t, c.salary),
+					EffectiveFrom: date(t, c.effective),
+				}
+			}
+
+			got, err := Compute(date(t, tt.month), changes)
+			if err != nil {
+				t.Fatalf("Compute: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d entries, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i, want := range tt.want {
+				checkEntry(t, got[i], want, tt.month)
+			}
+		})
+	}
+}
+
+func checkEntry(t *testing.T, got model.PayrollEntry, want entry, month string) {
+	t.Helper()
+	if got.CatID != want.catID || got.Amount.String() != want.amount || got.Month != month[:7] || got.Currency != model.DefaultCurrency {
+		t.Errorf("entry is cat %d, %s %s for %s, want cat %d, %s %s for %s",
+			got.CatID, got.Amount, got.Currency, got.Month, want.catID, want.amount, model.DefaultCurrency, month[:7])
+	}
+	if len(got.Periods) != len(want.periods) {
+		t.Fatalf("cat %d has %d periods, want %d: %+v", got.CatID, len(got.Periods), len(want.periods), got.Periods)
+	}
+	var sum int64
+	for i, p := range want.periods {
+		g := got.Periods[i]
+		sum += g.Amount.Cents()
+		if g.From.String() != p.from || g.To.String() != p.to || g.Days != p.days || g.Amount.String() != p.amount {
+			t.Errorf("period %d is %s..%s, %d days, %s; want %s..%s, %d days, %s",
+				i, g.From, g.To, g.Days, g.Amount, p.from, p.to, p.days, p.amount)
+		}
+	}
+	if sum != got.Amount.Cents() {
+		t.Errorf("periods add up to %d cents, total is %d", sum, got.Amount.Cents())
+	}
+}
+
+func TestProrateRoundsHalfUp(t *testing.T) {
+	tests := []struct {
+		cents             int64
+		days, daysInMonth int
+		want              int64
+	}{
+		{cents: 1, days: 1, daysInMonth: 2, want: 1},
+		{cents: 3, days: 1, daysInMonth: 2, want: 2},
+		{cents: 4, days: 1, daysInMonth: 10, want: 0},
+		{cents: 5, days: 1, daysInMonth: 10, want: 1},
+		{cents: 100000, days: 15, daysInMonth: 31, want: 48387},
+		{cents: 100000, days: 31, daysInMonth: 31, want: 100000},
+		{cents: 9999999999, days: 29, daysInMonth: 29, want: 9999999999},
+		{cents: 9999999999, days: 1, daysInMonth: 28, want: 357142857},
+	}
+	for _, tt := range tests {
+		if got := prorate(tt.cents, tt.days, tt.daysInMonth); got != tt.want {
+			t.Errorf("prorate(%d, %d, %d) = %d, want %d", tt.cents, tt.days, tt.daysInMonth, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"main/internal/audit"
+	"main/internal/model"
+	"main/internal/store"
+	"strings"
+)
+
+// Audited actions
+const (
+	actionCreate       = "create"
+	actionUpdate       = "update"
+	actionUpdateSalary = "update_salary"
+	actionUpdateNotes  = "update_notes"
+	actionDelete       = "delete"
+	actionAssign       = "assign"
+	actionUnassign     = "unassign"
+	actionReassign     = "reassign"
+	actionTransition   = "transition"
+	actionComplete     = "complete"
+)
+
+type AuditRepository struct {
+	conn
+}
+
+func NewAuditRepository(store store.Store) *AuditRepository {
+	return &AuditRepository{conn: newConn(store)}
+}
+
+// recordEvent stores an audit event within the transaction of the change it describes.
+// The actor and request ID are taken from ctx; a nil before or after is stored as NULL.
+func recordEvent(ctx context.Context, tx *sql.Tx, entity model.AuditEntity, entityID int, action string, before, after any) error {
+	beforeJSON, err := snapshotJSON(before)
+	if err != nil {
+		return err
+	}
+	afterJSON, err := snapshotJSON(after)
+	if err != nil {
+		return err
+	}
+
+	source := audit.FromContext(ctx)
+	query := `
+        INSERT INTO audit_events (actor, entity_type, entity_id, action, before, after, request_id)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+    `
+	_, err = tx.ExecContext(ctx, query, source.Actor, entity, entityID, action, beforeJSON, afterJSON, source.RequestID)
+	if err != nil {
+		return fmt.Errorf("unable to record audit event: %v", err)
+	}
+	return nil
+}
+
+// snapshotJSON encodes the state of an entity for the audit trail
+func snapshotJSON(entity any) (sql.NullString, error) {
+	if entity == nil {
+		return sql.NullString{}, nil
+	}
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return sql.NullString{}, fmt.Errorf("unable to encode audit snapshot: %v", err)
+	}
+	return sql.NullString{String: string(data), Valid: true}, nil
+}
+
+// GetEvents retrieves a page of audit events matching the filter ordered by ID.
+// The returned cursor is the ID to continue after, or 0 when there are no more events.
+func (r *AuditRepository) GetEvents(ctx context.Context, filter model.AuditFilter) ([]model.AuditEvent, int, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	var conditions []string
+	var args []interface{}
+	where := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	where("id > $%d", filter.AfterID)
+	if filter.EntityType != "" {
+		where("entity_type = $%d", filter.EntityType)
+	}
+	if filter.EntityID != nil {
+		where("entity_id = $%d", *filter.EntityID)
+	}
+	if filter.Actor != "" {
+		where("actor = $%d", filter.Actor)
+	}
+	if filter.From != nil {
+		where("occurred_at >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		where("occurred_at < $%d", *filter.To)
+	}
+
+	query := "SELECT id, actor, entity_type, entity_id, action, before, after, request_id, occurred_at FROM audit_events WHERE " +
+		strings.Join(conditions, " AND ") + " ORDER BY id"
+	if filter.Limit > 0 {
+		// One extra row tells whether another page follows
+		args = append(args, filter.Limit+1)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("unable to retrieve audit events: %v", err)
+	}
+	defer rows.Close()
+
+	events := []model.AuditEvent{}
+	for rows.Next() {
+		var event model.AuditEvent
+		var before, after []byte
+		err := rows.Scan(&event.ID, &event.Actor, &event.EntityType, &event.EntityID, &event.Action,
+			&before, &after, &event.RequestID, &event.OccurredAt)
+		if err != nil {
+			return nil, 0, fmt.Errorf("unable to scan audit event: %v", err)
+		}
+		event.Before, event.After = before, after
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("unable to retrieve audit events: %v", err)
+	}
+
+	nextCursor := 0
+	if filter.Limit > 0 && len(events) > filter.Limit {
+		events = events[:filter.Limit]
+		nextCursor = events[len(events)-1].ID
+	}
+	return events, nextCursor, nil
+}
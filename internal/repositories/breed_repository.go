@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"main/internal/model"
+	"main/internal/store"
+
+	"github.com/lib/pq"
+)
+
+type BreedRepository struct {
+	conn
+}
+
+func NewBreedRepository(store store.Store) *BreedRepository {
+	return &BreedRepository{conn: newConn(store)}
+}
+
+// LoadBreeds returns the persisted breed list and when it was fetched
+func (r *BreedRepository) LoadBreeds(ctx context.Context) ([]model.Breed, model.BreedSync, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	var sync model.BreedSync
+	err := r.db.QueryRowContext(ctx, `SELECT etag, fetched_at FROM breed_sync`).Scan(&sync.ETag, &sync.FetchedAt)
+	if err == sql.ErrNoRows {
+		return nil, model.BreedSync{}, nil
+	}
+	if err != nil {
+		return nil, model.BreedSync{}, fmt.Errorf("unable to load breed sync state: %v", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `SELECT id, name, origin, temperament, alt_names FROM breeds ORDER BY name`)
+	if err != nil {
+		return nil, model.BreedSync{}, fmt.Errorf("unable to load breeds: %v", err)
+	}
+	defer rows.Close()
+
+	var breeds []model.Breed
+	for rows.Next() {
+		var breed model.Breed
+		if err := rows.Scan(&breed.ID, &breed.Name, &breed.Origin, &breed.Temperament, pq.Array(&breed.AltNames)); err != nil {
+			return nil, model.BreedSync{}, fmt.Errorf("unable to scan breed: %v", err)
+		}
+		breeds = append(breeds, breed)
+	}
+	return breeds, sync, rows.Err()
+}
+
+// SaveBreeds replaces the persisted breed list within a transaction
+func (r *BreedRepository) SaveBreeds(ctx context.Context, breeds []model.Breed, sync model.BreedSync) error {
+	return r.inTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM breeds`); err != nil {
+			return fmt.Errorf("unable to clear breeds: %v", err)
+		}
+
+		query := `INSERT INTO breeds (id, name, origin, temperament, alt_names) VALUES ($1, $2, $3, $4, $5)`
+		for _, breed := range breeds {
+			_, err := tx.ExecContext(ctx, query, breed.ID, breed.Name, breed.Origin, breed.Temperament, pq.Array(breed.AltNames))
+			if err != nil {
+				return fmt.Errorf("unable to save breed %q: %v", breed.ID, err)
+			}
+		}
+
+		if err := saveBreedSync(ctx, tx, sync); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+// SaveBreedSync records a fetch that did not change the breed list
+func (r *BreedRepository) SaveBreedSync(ctx context.Context, sync model.BreedSync) error {
+	return r.inTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		if err := saveBreedSync(ctx, tx, sync); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+func saveBreedSync(ctx context.Context, tx *sql.Tx, sync model.BreedSync) error {
+	query := `
+        INSERT INTO breed_sync (id, etag, fetched_at) VALUES (TRUE, $1, $2)
+        ON CONFLICT (id) DO UPDATE SET etag = EXCLUDED.etag, fetched_at = EXCLUDED.fetched_at
+    `
+	if _, err := tx.ExecContext(ctx, query, sync.ETag, sync.FetchedAt); err != nil {
+		return fmt.Errorf("unable to save breed sync state: %v", err)
+	}
+	return nil
+}
@@ -1,86 +1,412 @@
 package repositories
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"main/internal/audit"
 	"main/internal/model"
 	"main/internal/store"
+	"strings"
 )
 
 type CatRepository struct {
-	db *sql.DB
+	conn
 }
 
 func NewCatRepository(store store.Store) *CatRepository {
-	return &CatRepository{db: store.DB}
+	return &CatRepository{conn: newConn(store)}
 }
 
 // Create creates a new cat in the database
-func (r *CatRepository) Create(cat *model.SpyCat) error {
-	query := `INSERT INTO cats (name, years_of_experience, breed, salary) VALUES ($1, $2, $3, $4) RETURNING id`
-	err := r.db.QueryRow(query, cat.Name, cat.ExperienceInYears, cat.Breed, cat.Salary).Scan(&cat.ID)
+func (r *CatRepository) Create(ctx context.Context, cat *model.SpyCat) error {
+	return r.inTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		query := `INSERT INTO cats (name, years_of_experience, breed, salary) VALUES ($1, $2, $3, $4) RETURNING id, version`
+		err := tx.QueryRowContext(ctx, query, cat.Name, cat.ExperienceInYears, cat.Breed, cat.Salary).Scan(&cat.ID, &cat.Version)
+		if err != nil {
+			return fmt.Errorf("unable to create cat: %v", err)
+		}
+		change := model.SalaryChange{CatID: cat.ID, Salary: cat.Salary, EffectiveFrom: model.Today(), Reason: reasonInitialSalary}
+		if err := recordSalaryChange(ctx, tx, &change); err != nil {
+			return err
+		}
+		if err := recordEvent(ctx, tx, model.AuditCat, cat.ID, actionCreate, nil, cat); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+// catSortColumns maps the sortable JSON fields of a cat to their columns
+var catSortColumns = map[string]string{
+	"id":                  "id",
+	"name":                "name",
+	"experience_in_years": "years_of_experience",
+	"breed":               "breed",
+	"salary":              "salary",
+}
+
+// GetAll retrieves the cats matching the filter together with the total number of matches
+func (r *CatRepository) GetAll(ctx context.Context, filter model.CatFilter) ([]model.SpyCat, int, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	keys, err := parseSort(filter.Sort, catSortColumns)
 	if err != nil {
-		return fmt.Errorf("unable to create cat: %v", err)
+		return nil, 0, err
 	}
-	return nil
+
+	var conditions []string
+	var args []interface{}
+	where := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if filter.Name != "" {
+		where(`name ILIKE '%%' || $%d || '%%'`, escapeLike(filter.Name))
+	}
+	if filter.Breed != "" {
+		where("LOWER(breed) = LOWER($%d)", filter.Breed)
+	}
+	if filter.MinExperience != nil {
+		where("years_of_experience >= $%d", *filter.MinExperience)
+	}
+	if filter.MaxExperience != nil {
+		where("years_of_experience <= $%d", *filter.MaxExperience)
+	}
+	if filter.MinSalary != nil {
+		where("salary >= $%d", *filter.MinSalary)
+	}
+	if filter.MaxSalary != nil {
+		where("salary <= $%d", *filter.MaxSalary)
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM cats"+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("unable to count cats: %v", err)
+	}
+
+	query := "SELECT id, name, years_of_experience, breed, salary, version FROM cats" + whereClause + orderBy(keys, catSortColumns)
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if filter.Offset > 0 {
+		args = append(args, filter.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("unable to retrieve cats: %v", err)
+	}
+	defer rows.Close()
+
+	cats := []model.SpyCat{}
+	for rows.Next() {
+		var cat model.SpyCat
+		if err := rows.Scan(&cat.ID, &cat.Name, &cat.ExperienceInYears, &cat.Breed, &cat.Salary, &cat.Version); err != nil {
+			return nil, 0, fmt.Errorf("unable to scan cat: %v", err)
+		}
+		cats = append(cats, cat)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("unable to retrieve cats: %v", err)
+	}
+	return cats, total, nil
 }
 
-// GetAll retrieves all cats from the database
-func (r *CatRepository) GetAll() ([]model.SpyCat, error) {
-	rows, err := r.db.Query("SELECT id, name, years_of_experience, breed, salary FROM cats")
+// GetByBreed retrieves all cats of the given breed, comparing breed names case-insensitively
+func (r *CatRepository) GetByBreed(ctx context.Context, breed string) ([]model.SpyCat, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, "SELECT id, name, years_of_experience, breed, salary, version FROM cats WHERE LOWER(breed) = LOWER($1) ORDER BY id", breed)
 	if err != nil {
 		return nil, fmt.Errorf("unable to retrieve cats: %v", err)
 	}
 	defer rows.Close()
 
-	var cats []model.SpyCat
+	cats := []model.SpyCat{}
 	for rows.Next() {
 		var cat model.SpyCat
-		if err := rows.Scan(&cat.ID, &cat.Name, &cat.ExperienceInYears, &cat.Breed, &cat.Salary); err != nil {
+		if err := rows.Scan(&cat.ID, &cat.Name, &cat.ExperienceInYears, &cat.Breed, &cat.Salary, &cat.Version); err != nil {
 			return nil, fmt.Errorf("unable to scan cat: %v", err)
 		}
 		cats = append(cats, cat)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("unable to retrieve cats: %v", err)
+	}
 	return cats, nil
 }
 
-// Delete removes a spy cat from the database
-func (r *CatRepository) Delete(catID int) error {
-	query := `DELETE FROM cats WHERE id = $1`
-	commandTag, err := r.db.Exec(query, catID)
+// lockCatMissions returns the IDs of the missions of a cat, locking them until the transaction ends
+func lockCatMissions(ctx context.Context, tx *sql.Tx, catID int) ([]int, error) {
+	rows, err := tx.QueryContext(ctx, `SELECT id FROM missions WHERE cat_id = $1 ORDER BY id FOR UPDATE`, catID)
 	if err != nil {
-		return fmt.Errorf("unable to delete cat: %v", err)
+		return nil, fmt.Errorf("unable to find missions of the cat: %v", err)
 	}
-	rowsAffcted, err := commandTag.RowsAffected()
-	if rowsAffcted == 0 || err != nil {
-		return fmt.Errorf("no cat found with id %d", catID)
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("unable to scan mission: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("unable to find missions of the cat: %v", err)
+	}
+	return ids, nil
+}
+
+// Delete removes a spy cat without an open mission from the database.
+// Its completed and aborted missions are left without a cat.
+func (r *CatRepository) Delete(ctx context.Context, catID int, version int) error {
+	return r.inTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		cat, err := lockCat(ctx, tx, catID, version)
+		if err != nil {
+			return err
+		}
+		// An open mission would be left without a cat it can neither be
+		// unassigned from nor reassigned from
+		if err := checkCatIdle(ctx, tx, catID); err != nil {
+			return err
+		}
+
+		// The foreign key clears cat_id of the closed missions, which changes them too
+		missionIDs, err := lockCatMissions(ctx, tx, catID)
+		if err != nil {
+			return err
+		}
+		missions := make([]model.Mission, 0, len(missionIDs))
+		for _, missionID := range missionIDs {
+			mission, err := getMission(ctx, tx, missionID)
+			if err != nil {
+				return err
+			}
+			missions = append(missions, mission)
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE missions SET version = version + 1 WHERE cat_id = $1`, catID); err != nil {
+			return fmt.Errorf("unable to update missions of the cat: %v", err)
+		}
+		for _, missionID := range missionIDs {
+			if err := closeAssignment(ctx, tx, missionID, reasonCatDeleted); err != nil {
+				return err
+			}
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM cats WHERE id = $1`, catID); err != nil {
+			return fmt.Errorf("unable to delete cat: %v", err)
+		}
+		// A deleted cat is owed nothing from today on
+		change := model.SalaryChange{CatID: catID, EffectiveFrom: model.Today(), Reason: reasonCatDeleted}
+		if err := recordSalaryChange(ctx, tx, &change); err != nil {
+			return err
+		}
+		if err := recordEvent(ctx, tx, model.AuditCat, catID, actionDelete, cat, nil); err != nil {
+			return err
+		}
+		for _, before := range missions {
+			if err := auditMission(ctx, tx, actionUnassign, before); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Update replaces the name, experience, breed and salary of a spy cat and sets its new version
+func (r *CatRepository) Update(ctx context.Context, cat *model.SpyCat, version int) error {
+	return r.inTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		before, err := lockCat(ctx, tx, cat.ID, version)
+		if err != nil {
+			return err
+		}
+
+		query := `
+        UPDATE cats
+        SET name = $1, years_of_experience = $2, breed = $3, salary = $4, version = version + 1
+        WHERE id = $5
+        RETURNING version
+    `
+		err = tx.QueryRowContext(ctx, query, cat.Name, cat.ExperienceInYears, cat.Breed, cat.Salary, cat.ID).Scan(&cat.Version)
+		if err != nil {
+			return fmt.Errorf("unable to update cat with id %d: %v", cat.ID, err)
+		}
+		if cat.Salary != before.Salary {
+			change := model.SalaryChange{CatID: cat.ID, Salary: cat.Salary, EffectiveFrom: model.Today(), Reason: reasonProfileUpdate}
+			if err := recordSalaryChange(ctx, tx, &change); err != nil {
+				return err
+			}
+		}
+		if err := recordEvent(ctx, tx, model.AuditCat, cat.ID, actionUpdate, before, cat); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+// UpdateSalary sets the salary of a spy cat and records the change in its salary history.
+// The change takes effect today unless it has an effective date; its ID, approver and
+// creation time are set from the stored record.
+func (r *CatRepository) UpdateSalary(ctx context.Context, change *model.SalaryChange, version int) error {
+	return r.inTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		before, err := lockCat(ctx, tx, change.CatID, version)
+		if err != nil {
+			return err
+		}
+
+		if change.EffectiveFrom.IsZero() {
+			change.EffectiveFrom = model.Today()
+		}
+		var latest sql.NullTime
+		query := `SELECT MAX(effective_from) FROM salary_changes WHERE cat_id = $1`
+		if err := tx.QueryRowContext(ctx, query, change.CatID).Scan(&latest); err != nil {
+			return fmt.Errorf("unable to retrieve salary history: %v", err)
+		}
+		var latestDate model.Date
+		if latest.Valid {
+			latestDate = model.NewDate(latest.Time)
+		}
+		if err := checkEffectiveDate(change.EffectiveFrom, latestDate); err != nil {
+			return err
+		}
+
+		query = `UPDATE cats SET salary = $1, version = version + 1 WHERE id = $2`
+		if _, err := tx.ExecContext(ctx, query, change.Salary, change.CatID); err != nil {
+			return fmt.Errorf("unable to update salary for cat with id %d: %v", change.CatID, err)
+		}
+		if err := recordSalaryChange(ctx, tx, change); err != nil {
+			return err
+		}
+		after := before
+		after.Salary = change.Salary
+		after.Version++
+		if err := recordEvent(ctx, tx, model.AuditCat, change.CatID, actionUpdateSalary, before, after); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+// recordSalaryChange stores a salary change within the transaction that makes it.
+// The change is approved by the actor carried by ctx.
+func recordSalaryChange(ctx context.Context, tx *sql.Tx, change *model.SalaryChange) error {
+	change.ApprovedBy = audit.FromContext(ctx).Actor
+	query := `
+        INSERT INTO salary_changes (cat_id, salary, effective_from, reason, approved_by)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id, created_at
+    `
+	row := tx.QueryRowContext(ctx, query, change.CatID, change.Salary, change.EffectiveFrom, change.Reason, change.ApprovedBy)
+	if err := row.Scan(&change.ID, &change.CreatedAt); err != nil {
+		return fmt.Errorf("unable to record salary change: %v", err)
 	}
 	return nil
 }
 
-// UpdateSalary updates the salary of a spy cat in the database
-func (r *CatRepository) UpdateSalary(catID int, newSalary float64) error {
-	query := `UPDATE cats SET salary = $1 WHERE id = $2`
-	commandTag, err := r.db.Exec(query, newSalary, catID)
+// GetSalaryHistory retrieves the salary changes of a cat in the order they take effect.
+// The history of a deleted cat is kept.
+func (r *CatRepository) GetSalaryHistory(ctx context.Context, catID int) ([]model.SalaryChange, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+        SELECT id, cat_id, salary, effective_from, reason, approved_by, created_at
+        FROM salary_changes
+        WHERE cat_id = $1
+        ORDER BY effective_from, id
+    `
+	changes, err := r.querySalaryChanges(ctx, query, catID)
 	if err != nil {
-		return fmt.Errorf("unable to update salary for cat with id %d: %v", catID, err)
+		return nil, err
 	}
-	rowsAffcted, err := commandTag.RowsAffected()
-	if rowsAffcted == 0 || err != nil {
-		return fmt.Errorf("no cat found with id %d", catID)
+	if len(changes) == 0 {
+		if _, err := r.GetByID(ctx, catID); err != nil {
+			return nil, err
+		}
 	}
-	return nil
+	return changes, nil
+}
+
+// GetSalaryChanges retrieves, for every cat, the last salary change before from and all
+// changes from from until to, ordered by cat, effective date and ID
+func (r *CatRepository) GetSalaryChanges(ctx context.Context, from, to model.Date) ([]model.SalaryChange, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+        SELECT id, cat_id, salary, effective_from, reason, approved_by, created_at
+        FROM salary_changes s
+        WHERE (effective_from >= $1 AND effective_from < $2)
+           OR id = (
+               SELECT id FROM salary_changes p
+               WHERE p.cat_id = s.cat_id AND p.effective_from < $1
+               ORDER BY p.effective_from DESC, p.id DESC
+               LIMIT 1
+           )
+        ORDER BY cat_id, effective_from, id
+    `
+	return r.querySalaryChanges(ctx, query, from, to)
+}
+
+func (r *CatRepository) querySalaryChanges(ctx context.Context, query string, args ...interface{}) ([]model.SalaryChange, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve salary changes: %v", err)
+	}
+	defer rows.Close()
+
+	changes := []model.SalaryChange{}
+	for rows.Next() {
+		var change model.SalaryChange
+		err := rows.Scan(&change.ID, &change.CatID, &change.Salary, &change.EffectiveFrom, &change.Reason, &change.ApprovedBy, &change.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("unable to scan salary change: %v", err)
+		}
+		changes = append(changes, change)
+	}
+	return changes, rows.Err()
+}
+
+// lockCat returns a cat with the expected version, locking its row until the transaction ends
+func lockCat(ctx context.Context, tx *sql.Tx, catID int, version int) (model.SpyCat, error) {
+	var cat model.SpyCat
+	query := `SELECT id, name, years_of_experience, breed, salary, version FROM cats WHERE id = $1 FOR UPDATE`
+	row := tx.QueryRowContext(ctx, query, catID)
+	if err := row.Scan(&cat.ID, &cat.Name, &cat.ExperienceInYears, &cat.Breed, &cat.Salary, &cat.Version); err != nil {
+		if err == sql.ErrNoRows {
+			return cat, fmt.Errorf("%w: id %d", ErrCatNotFound, catID)
+		}
+		return cat, fmt.Errorf("unable to find cat: %v", err)
+	}
+	return cat, checkVersion(cat.Version, version)
 }
 
 // GetByID retrieves a single spy cat from the database by its ID
-func (r *CatRepository) GetByID(catID int) (*model.SpyCat, error) {
-	query := `SELECT id, name, years_of_experience, breed, salary FROM cats WHERE id = $1`
-	row := r.db.QueryRow(query, catID)
+func (r *CatRepository) GetByID(ctx context.Context, catID int) (*model.SpyCat, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT id, name, years_of_experience, breed, salary, version FROM cats WHERE id = $1`
+	row := r.db.QueryRowContext(ctx, query, catID)
 
 	var cat model.SpyCat
-	if err := row.Scan(&cat.ID, &cat.Name, &cat.ExperienceInYears, &cat.Breed, &cat.Salary); err != nil {
-		if err.Error() == "no rows in result set" {
-			return nil, fmt.Errorf("no cat found with id %d", catID)
+	if err := row.Scan(&cat.ID, &cat.Name, &cat.ExperienceInYears, &cat.Breed, &cat.Salary, &cat.Version); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: id %d", ErrCatNotFound, catID)
 		}
 		return nil, fmt.Errorf("unable to retrieve cat with id %d: %v", catID, err)
 	}
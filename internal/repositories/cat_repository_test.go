@@ -0,0 +1,45 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"main/internal/model"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+var catColumns = []string{"id", "name", "years_of_experience", "breed", "salary", "version"}
+
+func TestGetByBreedFailsOnInterruptedRows(t *testing.T) {
+	s, mock := newMockStore(t)
+	mock.ExpectQuery("SELECT (.+) FROM cats WHERE LOWER\\(breed\\)").
+		WithArgs("Siamese").
+		WillReturnRows(sqlmock.NewRows(catColumns).
+			AddRow(1, "Tom", 3, "Siamese", "1000.00", 1).
+			AddRow(2, "Luna", 4, "Siamese", "2700.10", 1).
+			RowError(1, errors.New("connection reset")))
+
+	cats, err := NewCatRepository(s).GetByBreed(context.Background(), "Siamese")
+	if err == nil {
+		t.Fatalf("GetByBreed returned %d cats and no error for an interrupted result", len(cats))
+	}
+	expectationsMet(t, mock)
+}
+
+func TestGetAllFailsOnInterruptedRows(t *testing.T) {
+	s, mock := newMockStore(t)
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM cats").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery("SELECT (.+) FROM cats").
+		WillReturnRows(sqlmock.NewRows(catColumns).
+			AddRow(1, "Tom", 3, "Siamese", "1000.00", 1).
+			AddRow(2, "Luna", 4, "Siamese", "2700.10", 1).
+			RowError(1, errors.New("connection reset")))
+
+	cats, total, err := NewCatRepository(s).GetAll(context.Background(), model.CatFilter{})
+	if err == nil {
+		t.Fatalf("GetAll returned %d of %d cats and no error for an interrupted result", len(cats), total)
+	}
+	expectationsMet(t, mock)
+}
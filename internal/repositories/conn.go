@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"main/internal/store"
+	"time"
+)
+
+// conn is the database handle of a Postgres repository
+type conn struct {
+	db *sql.DB
+	timeouts
+}
+
+func newConn(store store.Store) conn {
+	return conn{db: store.DB, timeouts: timeouts{query: store.QueryTimeout, tx: store.TxTimeout}}
+}
+
+// inTx runs fn as a single unit of work: its statements are committed together
+// when fn succeeds and rolled back when it fails, panics or runs out of time.
+// fn must run every statement on tx with the ctx it is given.
+func (c conn) inTx(ctx context.Context, fn func(ctx context.Context, tx *sql.Tx) error) error {
+	ctx, cancel := c.withTxTimeout(ctx)
+	defer cancel()
+
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("unable to start transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(ctx, tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("unable to commit transaction: %v", err)
+	}
+	return nil
+}
+
+// timeouts bounds how long a Postgres repository waits for the database,
+// so that a slow query does not outlive the request that started it.
+// A zero timeout leaves only the deadline of the caller's context.
+type timeouts struct {
+	query time.Duration
+	tx    time.Duration
+}
+
+// withQueryTimeout limits a read
+func (t timeouts) withQueryTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return withTimeout(ctx, t.query)
+}
+
+// withTxTimeout limits a transaction from its start to its commit
+func (t timeouts) withTxTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return withTimeout(ctx, t.tx)
+}
+
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
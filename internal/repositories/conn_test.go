@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"main/internal/store"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// newMockStore returns a store backed by sqlmock, which fails the test on unexpected statements
+func newMockStore(t *testing.T) (store.Store, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return store.Store{DB: db}, mock
+}
+
+// expectationsMet waits briefly for the expected statements, since database/sql
+// rolls back a transaction whose context ends from a goroutine of its own
+func expectationsMet(t *testing.T, mock sqlmock.Sqlmock) {
+	t.Helper()
+	var err error
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(5 * time.Millisecond) {
+		if err = mock.ExpectationsWereMet(); err == nil {
+			return
+		}
+	}
+	t.Fatal(err)
+}
+
+func TestInTxCommitsOnSuccess(t *testing.T) {
+	s, mock := newMockStore(t)
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE cats").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := newConn(s).inTx(context.Background(), func(ctx context.Context, tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `UPDATE cats SET version = version + 1`)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("inTx: %v", err)
+	}
+	expectationsMet(t, mock)
+}
+
+func TestInTxRollsBackOnError(t *testing.T) {
+	s, mock := newMockStore(t)
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE cats").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectRollback()
+
+	failure := errors.New("second statement failed")
+	err := newConn(s).inTx(context.Background(), func(ctx context.Context, tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `UPDATE cats SET version = version + 1`); err != nil {
+			return err
+		}
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("inTx returned %v, want %v", err, failure)
+	}
+	expectationsMet(t, mock)
+}
+
+func TestInTxRollsBackOnPanic(t *testing.T) {
+	s, mock := newMockStore(t)
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE cats").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectRollback()
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("inTx swallowed the panic")
+			}
+		}()
+		newConn(s).inTx(context.Background(), func(ctx context.Context, tx *sql.Tx) error {
+			if _, err := tx.ExecContext(ctx, `UPDATE cats SET version = version + 1`); err != nil {
+				return err
+			}
+			panic("unit of work failed")
+		})
+	}()
+	expectationsMet(t, mock)
+}
+
+func TestInTxRollsBackOnTimeout(t *testing.T) {
+	s, mock := newMockStore(t)
+	s.TxTimeout = 20 * time.Millisecond
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE cats").WillDelayFor(time.Second).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectRollback()
+
+	start := time.Now()
+	err := newConn(s).inTx(context.Background(), func(ctx context.Context, tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `UPDATE cats SET version = version + 1`)
+		return err
+	})
+	if err == nil {
+		t.Fatal("inTx succeeded although the transaction timed out")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("inTx returned after %s, want it to give up at the transaction timeout", elapsed)
+	}
+	expectationsMet(t, mock)
+}
+
+func TestInTxReportsCommitFailure(t *testing.T) {
+	s, mock := newMockStore(t)
+	mock.ExpectBegin()
+	mock.ExpectCommit().WillReturnError(errors.New("connection reset"))
+
+	err := newConn(s).inTx(context.Background(), func(ctx context.Context, tx *sql.Tx) error {
+		return nil
+	})
+	if err == nil {
+		t.Fatal("inTx succeeded although the commit failed")
+	}
+	expectationsMet(t, mock)
+}
@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"main/internal/apperr"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrInvalidFilter        = apperr.New(apperr.ErrValidation, "invalid_filter", "invalid filter")
+	ErrInvalidEffectiveDate = apperr.New(apperr.ErrValidation, "invalid_effective_date", "invalid effective date")
+	ErrTargetLimit          = apperr.New(apperr.ErrValidation, "target_limit", "invalid number of targets")
+	ErrIllegalTransition    = apperr.New(apperr.ErrForbiddenState, "illegal_transition", "illegal mission status transition")
+	ErrOpenTargets          = apperr.New(apperr.ErrForbiddenState, "open_targets", "mission has incomplete targets")
+	ErrMissionClosed        = apperr.New(apperr.ErrForbiddenState, "mission_closed", "mission is closed")
+	ErrTargetCompleted      = apperr.New(apperr.ErrForbiddenState, "target_completed", "target is completed")
+	ErrMissionAssigned      = apperr.New(apperr.ErrForbiddenState, "mission_assigned", "mission is already assigned to a cat")
+	ErrMissionUnassigned    = apperr.New(apperr.ErrForbiddenState, "mission_unassigned", "mission has no assigned cat")
+	ErrMissionNotFound      = apperr.New(apperr.ErrNotFound, "mission_not_found", "mission not found")
+	ErrTargetNotFound       = apperr.New(apperr.ErrNotFound, "target_not_found", "target not found")
+	ErrCatNotFound          = apperr.New(apperr.ErrNotFound, "cat_not_found", "cat not found")
+	ErrCatBusy              = apperr.New(apperr.ErrConflict, "cat_busy", "cat already has an active mission")
+	ErrVersionMismatch      = apperr.New(apperr.ErrPreconditionFailed, "version_mismatch", "resource has been modified")
+)
+
+// checkVersion fails with ErrVersionMismatch unless the expected version is 0 or the current one
+func checkVersion(current, expected int) error {
+	if expected != 0 && current != expected {
+		return fmt.Errorf("%w: version is %d, not %d", ErrVersionMismatch, current, expected)
+	}
+	return nil
+}
+
+// activeCatIndex is the partial unique index allowing a single open mission per cat
+const activeCatIndex = "missions_one_active_per_cat"
+
+// isUniqueViolation reports whether err is a Postgres unique violation of the given constraint
+func isUniqueViolation(err error, constraint string) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == constraint
+}
@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"main/internal/audit"
+	"main/internal/model"
+	"time"
+)
+
+type MemoryAuditRepository struct {
+	store *MemoryStore
+}
+
+func NewMemoryAuditRepository(store *MemoryStore) *MemoryAuditRepository {
+	return &MemoryAuditRepository{store: store}
+}
+
+// recordEvent stores an audit event for a change. A nil before or after is left out.
+// The caller must hold the lock.
+func (s *MemoryStore) recordEvent(ctx context.Context, entity model.AuditEntity, entityID int, action string, before, after any) error {
+	source := audit.FromContext(ctx)
+	event := model.AuditEvent{
+		Actor:      source.Actor,
+		EntityType: entity,
+		EntityID:   entityID,
+		Action:     action,
+		RequestID:  source.RequestID,
+		OccurredAt: time.Now(),
+	}
+	var err error
+	if before != nil {
+		if event.Before, err = json.Marshal(before); err != nil {
+			return err
+		}
+	}
+	if after != nil {
+		if event.After, err = json.Marshal(after); err != nil {
+			return err
+		}
+	}
+
+	s.nextEventID++
+	event.ID = s.nextEventID
+	s.events = append(s.events, event)
+	return nil
+}
+
+// GetEvents returns a page of audit events matching the filter ordered by ID.
+// The returned cursor is the ID to continue after, or 0 when there are no more events.
+func (r *MemoryAuditRepository) GetEvents(ctx context.Context, filter model.AuditFilter) ([]model.AuditEvent, int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	events := []model.AuditEvent{}
+	for _, event := range r.store.events {
+		if event.ID > filter.AfterID && matchesAuditFilter(event, filter) {
+			events = append(events, event)
+		}
+	}
+
+	nextCursor := 0
+	if filter.Limit > 0 && len(events) > filter.Limit {
+		events = events[:filter.Limit]
+		nextCursor = events[len(events)-1].ID
+	}
+	return events, nextCursor, nil
+}
+
+func matchesAuditFilter(event model.AuditEvent, filter model.AuditFilter) bool {
+	switch {
+	case filter.EntityType != "" && event.EntityType != filter.EntityType:
+		return false
+	case filter.EntityID != nil && event.EntityID != *filter.EntityID:
+		return false
+	case filter.Actor != "" && event.Actor != filter.Actor:
+		return false
+	case filter.From != nil && event.OccurredAt.Before(*filter.From):
+		return false
+	case filter.To != nil && !event.OccurredAt.Before(*filter.To):
+		return false
+	}
+	return true
+}
@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"context"
+	"main/internal/model"
+)
+
+type MemoryBreedRepository struct {
+	store *MemoryStore
+}
+
+func NewMemoryBreedRepository(store *MemoryStore) *MemoryBreedRepository {
+	return &MemoryBreedRepository{store: store}
+}
+
+// LoadBreeds returns the stored breed list and when it was fetched
+func (r *MemoryBreedRepository) LoadBreeds(ctx context.Context) ([]model.Breed, model.BreedSync, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return append([]model.Breed(nil), r.store.breeds...), r.store.breedSync, nil
+}
+
+// SaveBreeds replaces the stored breed list
+func (r *MemoryBreedRepository) SaveBreeds(ctx context.Context, breeds []model.Breed, sync model.BreedSync) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.breeds = append([]model.Breed(nil), breeds...)
+	r.store.breedSync = sync
+	return nil
+}
+
+// SaveBreedSync records a fetch that did not change the breed list
+func (r *MemoryBreedRepository) SaveBreedSync(ctx context.Context, sync model.BreedSync) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.breedSync = sync
+	return nil
+}
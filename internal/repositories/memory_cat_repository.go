@@ -0,0 +1,280 @@
+package repositories
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"main/internal/audit"
+	"main/internal/model"
+	"sort"
+	"strings"
+	"time"
+)
+
+type MemoryCatRepository struct {
+	store *MemoryStore
+}
+
+func NewMemoryCatRepository(store *MemoryStore) *MemoryCatRepository {
+	return &MemoryCatRepository{store: store}
+}
+
+// Create stores a new cat and assigns its ID
+func (r *MemoryCatRepository) Create(ctx context.Context, cat *model.SpyCat) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.nextCatID++
+	cat.ID = r.store.nextCatID
+	cat.Version = 1
+	r.store.cats[cat.ID] = *cat
+	r.store.recordSalaryChange(ctx, model.SalaryChange{CatID: cat.ID, Salary: cat.Salary, EffectiveFrom: model.Today(), Reason: reasonInitialSalary})
+	return r.store.recordEvent(ctx, model.AuditCat, cat.ID, actionCreate, nil, cat)
+}
+
+// GetAll returns the cats matching the filter together with the total number of matches
+func (r *MemoryCatRepository) GetAll(ctx context.Context, filter model.CatFilter) ([]model.SpyCat, int, error) {
+	keys, err := parseSort(filter.Sort, catSortColumns)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	cats := []model.SpyCat{}
+	for _, cat := range r.store.cats {
+		if matchesCatFilter(cat, filter) {
+			cats = append(cats, cat)
+		}
+	}
+	sort.Slice(cats, func(i, j int) bool {
+		for _, key := range keys {
+			if c := compareCats(cats[i], cats[j], key.field); c != 0 {
+				return (c < 0) != key.desc
+			}
+		}
+		return cats[i].ID < cats[j].ID
+	})
+
+	total := len(cats)
+	return paginate(cats, filter.Limit, filter.Offset), total, nil
+}
+
+func matchesCatFilter(cat model.SpyCat, filter model.CatFilter) bool {
+	switch {
+	case filter.Name != "" && !strings.Contains(strings.ToLower(cat.Name), strings.ToLower(filter.Name)):
+		return false
+	case filter.Breed != "" && !strings.EqualFold(cat.Breed, filter.Breed):
+		return false
+	case filter.MinExperience != nil && cat.ExperienceInYears < *filter.MinExperience:
+		return false
+	case filter.MaxExperience != nil && cat.ExperienceInYears > *filter.MaxExperience:
+		return false
+	case filter.MinSalary != nil && cat.Salary.Cmp(*filter.MinSalary) < 0:
+		return false
+	case filter.MaxSalary != nil && cat.Salary.Cmp(*filter.MaxSalary) > 0:
+		return false
+	}
+	return true
+}
+
+func compareCats(a, b model.SpyCat, field string) int {
+	switch field {
+	case "id":
+		return cmp.Compare(a.ID, b.ID)
+	case "name":
+		return strings.Compare(a.Name, b.Name)
+	case "experience_in_years":
+		return cmp.Compare(a.ExperienceInYears, b.ExperienceInYears)
+	case "breed":
+		return strings.Compare(a.Breed, b.Breed)
+	case "salary":
+		return a.Salary.Cmp(b.Salary)
+	}
+	return 0
+}
+
+// GetByBreed returns the cats of the given breed ordered by ID, comparing breed names case-insensitively
+func (r *MemoryCatRepository) GetByBreed(ctx context.Context, breed string) ([]model.SpyCat, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	cats := []model.SpyCat{}
+	for _, cat := range r.store.cats {
+		if strings.EqualFold(cat.Breed, breed) {
+			cats = append(cats, cat)
+		}
+	}
+	sort.Slice(cats, func(i, j int) bool { return cats[i].ID < cats[j].ID })
+	return cats, nil
+}
+
+// Delete removes a cat without an open mission and detaches it from its closed missions
+func (r *MemoryCatRepository) Delete(ctx context.Context, catID int, version int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	cat, err := r.store.checkCat(catID, version)
+	if err != nil {
+		return err
+	}
+	if err := r.store.checkCatIdle(catID); err != nil {
+		return err
+	}
+	delete(r.store.cats, catID)
+	// A deleted cat is owed nothing from today on
+	r.store.recordSalaryChange(ctx, model.SalaryChange{CatID: catID, EffectiveFrom: model.Today(), Reason: reasonCatDeleted})
+
+	if err := r.store.recordEvent(ctx, model.AuditCat, catID, actionDelete, cat, nil); err != nil {
+		return err
+	}
+
+	var ids []int
+	for id, mission := range r.store.missions {
+		if mission.CatID == catID {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		mission := r.store.missions[id]
+		before := snapshotMission(mission)
+		mission.CatID = 0
+		mission.Version++
+		r.store.closeAssignment(mission.ID, reasonCatDeleted)
+		if err := r.store.recordEvent(ctx, model.AuditMission, mission.ID, actionUnassign, before, snapshotMission(mission)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Update replaces the name, experience, breed and salary of a cat and sets its new version
+func (r *MemoryCatRepository) Update(ctx context.Context, cat *model.SpyCat, version int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	before, err := r.store.checkCat(cat.ID, version)
+	if err != nil {
+		return err
+	}
+	cat.Version = before.Version + 1
+	r.store.cats[cat.ID] = *cat
+	if cat.Salary != before.Salary {
+		r.store.recordSalaryChange(ctx, model.SalaryChange{CatID: cat.ID, Salary: cat.Salary, EffectiveFrom: model.Today(), Reason: reasonProfileUpdate})
+	}
+	return r.store.recordEvent(ctx, model.AuditCat, cat.ID, actionUpdate, before, cat)
+}
+
+// UpdateSalary sets the salary of a cat and records the change in its salary history.
+// The change takes effect today unless it has an effective date; its ID, approver and
+// creation time are set from the stored record.
+func (r *MemoryCatRepository) UpdateSalary(ctx context.Context, change *model.SalaryChange, version int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	before, err := r.store.checkCat(change.CatID, version)
+	if err != nil {
+		return err
+	}
+
+	if change.EffectiveFrom.IsZero() {
+		change.EffectiveFrom = model.Today()
+	}
+	var latest model.Date
+	for _, recorded := range r.store.salaryChanges {
+		if recorded.CatID == change.CatID && recorded.EffectiveFrom.After(latest) {
+			latest = recorded.EffectiveFrom
+		}
+	}
+	if err := checkEffectiveDate(change.EffectiveFrom, latest); err != nil {
+		return err
+	}
+
+	after := before
+	after.Salary = change.Salary
+	after.Version++
+	r.store.cats[change.CatID] = after
+	*change = r.store.recordSalaryChange(ctx, *change)
+	return r.store.recordEvent(ctx, model.AuditCat, change.CatID, actionUpdateSalary, before, after)
+}
+
+// recordSalaryChange stores a salary change approved by the actor carried by ctx
+// and returns it as stored. The caller must hold the lock.
+func (s *MemoryStore) recordSalaryChange(ctx context.Context, change model.SalaryChange) model.SalaryChange {
+	s.nextSalaryChangeID++
+	change.ID = s.nextSalaryChangeID
+	change.ApprovedBy = audit.FromContext(ctx).Actor
+	change.CreatedAt = time.Now()
+	s.salaryChanges = append(s.salaryChanges, change)
+	return change
+}
+
+// GetSalaryHistory returns the salary changes of a cat in the order they take effect.
+// The history of a deleted cat is kept.
+func (r *MemoryCatRepository) GetSalaryHistory(ctx context.Context, catID int) ([]model.SalaryChange, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	changes := []model.SalaryChange{}
+	for _, change := range r.store.salaryChanges {
+		if change.CatID == catID {
+			changes = append(changes, change)
+		}
+	}
+	if _, ok := r.store.cats[catID]; !ok && len(changes) == 0 {
+		return nil, fmt.Errorf("%w: id %d", ErrCatNotFound, catID)
+	}
+	sortSalaryChanges(changes)
+	return changes, nil
+}
+
+// GetSalaryChanges returns, for every cat, the last salary change before from and all
+// changes from from until to, ordered by cat, effective date and ID
+func (r *MemoryCatRepository) GetSalaryChanges(ctx context.Context, from, to model.Date) ([]model.SalaryChange, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	changes := append([]model.SalaryChange(nil), r.store.salaryChanges...)
+	sortSalaryChanges(changes)
+
+	result := []model.SalaryChange{}
+	for i, change := range changes {
+		switch {
+		case !change.EffectiveFrom.Before(to):
+		case !change.EffectiveFrom.Before(from):
+			result = append(result, change)
+		case i+1 == len(changes) || changes[i+1].CatID != change.CatID || !changes[i+1].EffectiveFrom.Before(from):
+			// The last change before the period
+			result = append(result, change)
+		}
+	}
+	return result, nil
+}
+
+// sortSalaryChanges orders salary changes by cat, effective date and ID
+func sortSalaryChanges(changes []model.SalaryChange) {
+	sort.Slice(changes, func(i, j int) bool {
+		a, b := changes[i], changes[j]
+		if a.CatID != b.CatID {
+			return a.CatID < b.CatID
+		}
+		if a.EffectiveFrom != b.EffectiveFrom {
+			return a.EffectiveFrom.Before(b.EffectiveFrom)
+		}
+		return a.ID < b.ID
+	})
+}
+
+// GetByID returns a single cat by its ID
+func (r *MemoryCatRepository) GetByID(ctx context.Context, catID int) (*model.SpyCat, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	cat, ok := r.store.cats[catID]
+	if !ok {
+		return nil, fmt.Errorf("%w: id %d", ErrCatNotFound, catID)
+	}
+	return &cat, nil
+}
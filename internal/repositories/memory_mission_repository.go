@@ -0,0 +1,476 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"main/internal/config"
+	"main/internal/model"
+	"sort"
+	"strings"
+	"time"
+)
+
+type MemoryMissionRepository struct {
+	store  *MemoryStore
+	limits config.Missions
+}
+
+func NewMemoryMissionRepository(store *MemoryStore, limits config.Missions) *MemoryMissionRepository {
+	return &MemoryMissionRepository{store: store, limits: limits}
+}
+
+// AssignCat assigns a cat to a mission that has no cat yet
+func (r *MemoryMissionRepository) AssignCat(ctx context.Context, missionID int, catID int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	mission, ok := r.store.missions[missionID]
+	if !ok {
+		return fmt.Errorf("%w: id %d", ErrMissionNotFound, missionID)
+	}
+	if mission.CatID != 0 {
+		return fmt.Errorf("%w: cat %d", ErrMissionAssigned, mission.CatID)
+	}
+	if err := checkTransition(mission.Status, model.MissionAssigned, catID); err != nil {
+		return err
+	}
+	if err := r.checkCatAvailable(catID); err != nil {
+		return err
+	}
+
+	before := snapshotMission(mission)
+	mission.CatID = catID
+	mission.Version++
+	r.store.openAssignment(missionID, catID)
+	transitionMemoryMission(mission, model.MissionAssigned, "")
+	return r.store.recordEvent(ctx, model.AuditMission, missionID, actionAssign, before, snapshotMission(mission))
+}
+
+// UnassignCat removes the cat from an assigned mission, which returns to draft,
+// or from a completed or aborted mission, which keeps its status
+func (r *MemoryMissionRepository) UnassignCat(ctx context.Context, missionID int, reason string, version int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	mission, err := r.store.checkMission(missionID, version)
+	if err != nil {
+		return err
+	}
+	if mission.CatID == 0 {
+		return fmt.Errorf("%w: id %d", ErrMissionUnassigned, missionID)
+	}
+	closed := mission.Status.Closed()
+	if !closed {
+		if err := checkTransition(mission.Status, model.MissionDraft, 0); err != nil {
+			return err
+		}
+	}
+
+	before := snapshotMission(mission)
+	mission.CatID = 0
+	mission.Version++
+	r.store.closeAssignment(missionID, reason)
+	if !closed {
+		transitionMemoryMission(mission, model.MissionDraft, reason)
+	}
+	return r.store.recordEvent(ctx, model.AuditMission, missionID, actionUnassign, before, snapshotMission(mission))
+}
+
+// ReassignCat replaces the cat of an open mission, keeping its status
+func (r *MemoryMissionRepository) ReassignCat(ctx context.Context, missionID int, catID int, reason string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	mission, ok := r.store.missions[missionID]
+	if !ok {
+		return fmt.Errorf("%w: id %d", ErrMissionNotFound, missionID)
+	}
+	if mission.CatID == 0 {
+		return fmt.Errorf("%w: id %d", ErrMissionUnassigned, missionID)
+	}
+	if mission.Status.Closed() {
+		return fmt.Errorf("%w: a %s mission cannot be reassigned", ErrMissionClosed, mission.Status)
+	}
+	if mission.CatID == catID {
+		return fmt.Errorf("%w: cat %d", ErrMissionAssigned, catID)
+	}
+	if err := r.checkCatAvailable(catID); err != nil {
+		return err
+	}
+
+	before := snapshotMission(mission)
+	mission.CatID = catID
+	mission.Version++
+	r.store.closeAssignment(missionID, reason)
+	r.store.openAssignment(missionID, catID)
+	return r.store.recordEvent(ctx, model.AuditMission, missionID, actionReassign, before, snapshotMission(mission))
+}
+
+// GetAssignments returns the assignment history matching the filter in the order the cats were assigned
+func (r *MemoryMissionRepository) GetAssignments(ctx context.Context, filter model.AssignmentFilter) ([]model.MissionAssignment, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	assignments := []model.MissionAssignment{}
+	for _, assignment := range r.store.assignments {
+		if filter.MissionID != nil && assignment.MissionID != *filter.MissionID {
+			continue
+		}
+		if filter.CatID != nil && assignment.CatID != *filter.CatID {
+			continue
+		}
+		if assignment.UnassignedAt != nil {
+			unassignedAt := *assignment.UnassignedAt
+			assignment.UnassignedAt = &unassignedAt
+		}
+		assignments = append(assignments, assignment)
+	}
+	return assignments, nil
+}
+
+// checkCatAvailable verifies that the cat exists and has no open mission.
+// The caller must hold the lock.
+func (r *MemoryMissionRepository) checkCatAvailable(catID int) error {
+	if _, ok := r.store.cats[catID]; !ok {
+		return fmt.Errorf("%w: id %d", ErrCatNotFound, catID)
+	}
+	return r.store.checkCatIdle(catID)
+}
+
+// UpdateNotes updates the notes of a target while both it and its mission are incomplete
+func (r *MemoryMissionRepository) UpdateNotes(ctx context.Context, targetID int, notes string, version int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	mission, i, ok := r.store.findTarget(targetID)
+	if !ok {
+		return fmt.Errorf("%w: id %d", ErrTargetNotFound, targetID)
+	}
+	if mission.Status.Closed() {
+		return fmt.Errorf("%w: notes of a %s mission cannot be changed", ErrMissionClosed, mission.Status)
+	}
+	if mission.Targets[i].Complete {
+		return fmt.Errorf("%w: its notes cannot be changed", ErrTargetCompleted)
+	}
+	if err := checkVersion(mission.Targets[i].Version, version); err != nil {
+		return err
+	}
+
+	before := mission.Targets[i]
+	mission.Targets[i].Notes = notes
+	mission.Targets[i].Version++
+	mission.Version++
+	return r.store.recordEvent(ctx, model.AuditTarget, targetID, actionUpdateNotes, before, mission.Targets[i])
+}
+
+// Create stores a new mission together with its targets
+func (r *MemoryMissionRepository) Create(ctx context.Context, mission *model.Mission) error {
+	if err := checkTargetCount(r.limits, len(mission.Targets)); err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if mission.CatID != 0 {
+		if err := r.checkCatAvailable(mission.CatID); err != nil {
+			return err
+		}
+	}
+
+	r.store.nextMissionID++
+	mission.ID = r.store.nextMissionID
+	mission.Status = model.MissionDraft
+	if mission.CatID != 0 {
+		mission.Status = model.MissionAssigned
+	}
+	mission.Completed = false
+	mission.History = []model.MissionTransition{{To: mission.Status, At: time.Now()}}
+	mission.Version = 1
+
+	for i := range mission.Targets {
+		r.store.nextTargetID++
+		mission.Targets[i].ID = r.store.nextTargetID
+		mission.Targets[i].Version = 1
+		r.store.targetMission[mission.Targets[i].ID] = mission.ID
+	}
+
+	stored := copyMission(mission)
+	r.store.missions[mission.ID] = &stored
+	if mission.CatID != 0 {
+		r.store.openAssignment(mission.ID, mission.CatID)
+	}
+	mission.History = nil
+	return r.store.recordEvent(ctx, model.AuditMission, mission.ID, actionCreate, nil, mission)
+}
+
+// Delete removes a mission and its targets unless the mission is assigned to a cat
+func (r *MemoryMissionRepository) Delete(ctx context.Context, missionID int, version int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	mission, err := r.store.checkMission(missionID, version)
+	if err != nil {
+		return err
+	}
+	if mission.CatID != 0 {
+		return fmt.Errorf("%w: unassign the cat before deleting the mission", ErrMissionAssigned)
+	}
+
+	for _, target := range mission.Targets {
+		delete(r.store.targetMission, target.ID)
+	}
+	delete(r.store.missions, missionID)
+
+	assignments := r.store.assignments[:0]
+	for _, assignment := range r.store.assignments {
+		if assignment.MissionID != missionID {
+			assignments = append(assignments, assignment)
+		}
+	}
+	r.store.assignments = assignments
+	return r.store.recordEvent(ctx, model.AuditMission, missionID, actionDelete, snapshotMission(mission), nil)
+}
+
+// Complete marks a mission as completed. A mission with incomplete targets
+// is only completed when forced, and the reason is kept in its history.
+func (r *MemoryMissionRepository) Complete(ctx context.Context, missionID int, force bool, reason string, version int) error {
+	return r.transition(ctx, missionID, model.MissionCompleted, reason, force, version)
+}
+
+// Transition moves a mission to another status if the lifecycle allows it
+func (r *MemoryMissionRepository) Transition(ctx context.Context, missionID int, to model.MissionStatus, reason string) error {
+	return r.transition(ctx, missionID, to, reason, false, 0)
+}
+
+func (r *MemoryMissionRepository) transition(ctx context.Context, missionID int, to model.MissionStatus, reason string, force bool, version int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	mission, err := r.store.checkMission(missionID, version)
+	if err != nil {
+		return err
+	}
+	if err := checkTransition(mission.Status, to, mission.CatID); err != nil {
+		return err
+	}
+	if open := openTargets(mission); to == model.MissionCompleted && !force && open > 0 {
+		return fmt.Errorf("%w: %d of them still open", ErrOpenTargets, open)
+	}
+
+	before := snapshotMission(mission)
+	transitionMemoryMission(mission, to, reason)
+	action := actionTransition
+	if to == model.MissionCompleted {
+		action = actionComplete
+	}
+	return r.store.recordEvent(ctx, model.AuditMission, missionID, action, before, snapshotMission(mission))
+}
+
+// openTargets counts the incomplete targets of a mission
+func openTargets(mission *model.Mission) int {
+	open := 0
+	for _, target := range mission.Targets {
+		if !target.Complete {
+			open++
+		}
+	}
+	return open
+}
+
+// MarkTargetAsComplete marks a target of an active mission as complete.
+// The first completed target moves an assigned mission to in progress and
+// completing the last open target completes the mission.
+// It reports whether the mission was completed.
+func (r *MemoryMissionRepository) MarkTargetAsComplete(ctx context.Context, targetID int, version int) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	mission, i, ok := r.store.findTarget(targetID)
+	if !ok {
+		return false, fmt.Errorf("%w: id %d", ErrTargetNotFound, targetID)
+	}
+	if mission.Targets[i].Complete {
+		return false, fmt.Errorf("%w: id %d", ErrTargetCompleted, targetID)
+	}
+	if err := checkVersion(mission.Targets[i].Version, version); err != nil {
+		return false, err
+	}
+
+	missionBefore := snapshotMission(mission)
+	switch mission.Status {
+	case model.MissionInProgress:
+	case model.MissionAssigned:
+		transitionMemoryMission(mission, model.MissionInProgress, "")
+	default:
+		return false, &TransitionError{From: mission.Status, To: model.MissionInProgress, Reason: "targets can only be completed on assigned missions"}
+	}
+
+	before := mission.Targets[i]
+	mission.Targets[i].Complete = true
+	mission.Targets[i].Version++
+	mission.Version++
+	if err := r.store.recordEvent(ctx, model.AuditTarget, targetID, actionComplete, before, mission.Targets[i]); err != nil {
+		return false, err
+	}
+	// The status change of the mission is recorded as well
+	missionCompleted := openTargets(mission) == 0
+	action := actionTransition
+	if missionCompleted {
+		transitionMemoryMission(mission, model.MissionCompleted, "all targets completed")
+		action = actionComplete
+	}
+	if mission.Status != missionBefore.Status {
+		err := r.store.recordEvent(ctx, model.AuditMission, mission.ID, action, missionBefore, snapshotMission(mission))
+		if err != nil {
+			return false, err
+		}
+	}
+	return missionCompleted, nil
+}
+
+// DeleteTarget removes an incomplete target from its mission
+func (r *MemoryMissionRepository) DeleteTarget(ctx context.Context, targetID int, version int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	mission, i, ok := r.store.findTarget(targetID)
+	if !ok {
+		return fmt.Errorf("%w: id %d", ErrTargetNotFound, targetID)
+	}
+	if mission.Targets[i].Complete {
+		return fmt.Errorf("%w: it cannot be deleted", ErrTargetCompleted)
+	}
+	if err := checkVersion(mission.Targets[i].Version, version); err != nil {
+		return err
+	}
+	if err := checkTargetCount(r.limits, len(mission.Targets)-1); err != nil {
+		return err
+	}
+
+	before := mission.Targets[i]
+	mission.Targets = append(mission.Targets[:i], mission.Targets[i+1:]...)
+	mission.Version++
+	delete(r.store.targetMission, targetID)
+	return r.store.recordEvent(ctx, model.AuditTarget, targetID, actionDelete, before, nil)
+}
+
+// AddTarget adds a new target to an incomplete mission
+func (r *MemoryMissionRepository) AddTarget(ctx context.Context, missionID int, target *model.Target) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	mission, ok := r.store.missions[missionID]
+	if !ok {
+		return fmt.Errorf("%w: id %d", ErrMissionNotFound, missionID)
+	}
+	if mission.Status.Closed() {
+		return fmt.Errorf("%w: no new targets can be added to a %s mission", ErrMissionClosed, mission.Status)
+	}
+	if err := checkTargetCount(r.limits, len(mission.Targets)+1); err != nil {
+		return err
+	}
+
+	r.store.nextTargetID++
+	target.ID = r.store.nextTargetID
+	target.Version = 1
+	mission.Targets = append(mission.Targets, *target)
+	mission.Version++
+	r.store.targetMission[target.ID] = missionID
+	return r.store.recordEvent(ctx, model.AuditTarget, target.ID, actionCreate, nil, target)
+}
+
+// GetAll returns a page of missions matching the filter ordered by ID.
+// The returned cursor is the ID to continue after, or 0 when there are no more missions.
+func (r *MemoryMissionRepository) GetAll(ctx context.Context, filter model.MissionFilter) ([]model.Mission, int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	missions := []model.Mission{}
+	for _, mission := range r.store.missions {
+		if mission.ID > filter.AfterID && matchesMissionFilter(mission, filter) {
+			result := copyMission(mission)
+			if result.Targets == nil {
+				result.Targets = []model.Target{}
+			}
+			result.History = nil
+			missions = append(missions, result)
+		}
+	}
+	sort.Slice(missions, func(i, j int) bool { return missions[i].ID < missions[j].ID })
+
+	nextCursor := 0
+	if filter.Limit > 0 && len(missions) > filter.Limit {
+		missions = missions[:filter.Limit]
+		nextCursor = missions[len(missions)-1].ID
+	}
+	return missions, nextCursor, nil
+}
+
+func matchesMissionFilter(mission *model.Mission, filter model.MissionFilter) bool {
+	switch {
+	case filter.Completed != nil && (mission.Status == model.MissionCompleted) != *filter.Completed:
+		return false
+	case filter.CatID != nil && mission.CatID != *filter.CatID:
+		return false
+	case filter.Unassigned && mission.CatID != 0:
+		return false
+	}
+
+	if filter.Country != "" {
+		found := false
+		for _, target := range mission.Targets {
+			if strings.EqualFold(target.Country, filter.Country) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if filter.HasIncompleteTargets != nil {
+		incomplete := false
+		for _, target := range mission.Targets {
+			if !target.Complete {
+				incomplete = true
+				break
+			}
+		}
+		if incomplete != *filter.HasIncompleteTargets {
+			return false
+		}
+	}
+	return true
+}
+
+// GetByID returns a single mission with its targets
+func (r *MemoryMissionRepository) GetByID(ctx context.Context, id int) (model.Mission, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	mission, ok := r.store.missions[id]
+	if !ok {
+		return model.Mission{}, fmt.Errorf("%w: id %d", ErrMissionNotFound, id)
+	}
+	result := copyMission(mission)
+	if result.Targets == nil {
+		result.Targets = []model.Target{}
+	}
+	return result, nil
+}
+
+// transitionMemoryMission sets the mission status and records the transition.
+// The caller must hold the lock.
+func transitionMemoryMission(mission *model.Mission, to model.MissionStatus, reason string) {
+	mission.History = append(mission.History, model.MissionTransition{
+		From:   mission.Status,
+		To:     to,
+		Reason: reason,
+		At:     time.Now(),
+	})
+	mission.Status = to
+	mission.Completed = to == model.MissionCompleted
+	mission.Version++
+}
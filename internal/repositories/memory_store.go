@@ -0,0 +1,145 @@
+package repositories
+
+import (
+	"fmt"
+	"main/internal/model"
+	"sync"
+	"time"
+)
+
+// MemoryStore holds cats, missions, targets, breeds, salary history and the audit trail in process memory.
+// It is shared by the in-memory repositories so that rules spanning
+// several entities behave the same way as the Postgres schema.
+type MemoryStore struct {
+	mu sync.RWMutex
+
+	cats     map[int]model.SpyCat
+	missions map[int]*model.Mission
+	// targetMission maps a target ID to the ID of the mission that owns it
+	targetMission map[int]int
+	// assignments is the assignment history in the order cats were assigned
+	assignments []model.MissionAssignment
+	// events is the audit trail in the order the changes were made
+	events []model.AuditEvent
+	// salaryChanges is the salary history of all cats in the order the changes were made
+	salaryChanges []model.SalaryChange
+
+	breeds    []model.Breed
+	breedSync model.BreedSync
+
+	nextCatID     int
+	nextMissionID int
+	nextTargetID  int
+	nextEventID   int
+
+	nextSalaryChangeID int
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		cats:          make(map[int]model.SpyCat),
+		missions:      make(map[int]*model.Mission),
+		targetMission: make(map[int]int),
+	}
+}
+
+// checkCat returns the cat if it exists and has the expected version.
+// The caller must hold the lock.
+func (s *MemoryStore) checkCat(catID int, version int) (model.SpyCat, error) {
+	cat, ok := s.cats[catID]
+	if !ok {
+		return cat, fmt.Errorf("%w: id %d", ErrCatNotFound, catID)
+	}
+	return cat, checkVersion(cat.Version, version)
+}
+
+// checkCatIdle fails with ErrCatBusy if the cat has an open mission.
+// The caller must hold the lock.
+func (s *MemoryStore) checkCatIdle(catID int) error {
+	for _, mission := range s.missions {
+		if mission.CatID == catID && !mission.Status.Closed() {
+			return fmt.Errorf("%w: cat %d", ErrCatBusy, catID)
+		}
+	}
+	return nil
+}
+
+// checkMission returns the mission if it exists and has the expected version.
+// The caller must hold the lock.
+func (s *MemoryStore) checkMission(missionID int, version int) (*model.Mission, error) {
+	mission, ok := s.missions[missionID]
+	if !ok {
+		return nil, fmt.Errorf("%w: id %d", ErrMissionNotFound, missionID)
+	}
+	return mission, checkVersion(mission.Version, version)
+}
+
+// findTarget returns the mission owning the target and the target's index in it.
+// The caller must hold the lock.
+func (s *MemoryStore) findTarget(targetID int) (*model.Mission, int, bool) {
+	missionID, ok := s.targetMission[targetID]
+	if !ok {
+		return nil, 0, false
+	}
+	mission := s.missions[missionID]
+	for i, target := range mission.Targets {
+		if target.ID == targetID {
+			return mission, i, true
+		}
+	}
+	return nil, 0, false
+}
+
+// openAssignment starts a new entry in the assignment history of a mission.
+// The caller must hold the lock.
+func (s *MemoryStore) openAssignment(missionID int, catID int) {
+	s.assignments = append(s.assignments, model.MissionAssignment{
+		MissionID:  missionID,
+		CatID:      catID,
+		AssignedAt: time.Now(),
+	})
+}
+
+// closeAssignment ends the current assignment of a mission with the given reason.
+// The caller must hold the lock.
+func (s *MemoryStore) closeAssignment(missionID int, reason string) {
+	for i := range s.assignments {
+		if s.assignments[i].MissionID == missionID && s.assignments[i].UnassignedAt == nil {
+			now := time.Now()
+			s.assignments[i].UnassignedAt = &now
+			s.assignments[i].Reason = reason
+		}
+	}
+}
+
+// copyMission returns a copy of the mission that does not share the targets or history slices
+func copyMission(mission *model.Mission) model.Mission {
+	result := *mission
+	if mission.Targets != nil {
+		result.Targets = append([]model.Target(nil), mission.Targets...)
+	}
+	if mission.History != nil {
+		result.History = append([]model.MissionTransition(nil), mission.History...)
+	}
+	return result
+}
+
+// snapshotMission returns a copy of the mission without its history for the audit trail
+func snapshotMission(mission *model.Mission) model.Mission {
+	result := copyMission(mission)
+	result.History = nil
+	return result
+}
+
+// paginate returns the page of items selected by limit and offset; a limit of zero means no limit
+func paginate[T any](items []T, limit, offset int) []T {
+	if offset >= len(items) {
+		return items[:0]
+	}
+	items = items[offset:]
+	if limit > 0 && limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
@@ -4,312 +4,887 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"main/internal/config"
 	"main/internal/model"
 	"main/internal/store"
+	"strings"
+
+	"github.com/lib/pq"
 )
 
 type MissionRepository struct {
-	db *sql.DB
+	conn
+	limits config.Missions
 }
 
-func NewMissionRepository(store store.Store) *MissionRepository {
-	return &MissionRepository{db: store.DB}
+func NewMissionRepository(store store.Store, limits config.Missions) *MissionRepository {
+	return &MissionRepository{conn: newConn(store), limits: limits}
 }
 
 // AssignCat - Призначає кота до місії
-func (r *MissionRepository) AssignCat(missionID int, catID int) error {
-	query := `UPDATE missions SET cat_id = $1 WHERE id = $2 AND cat_id IS NULL`
-	result, err := r.db.Exec(query, catID, missionID)
+func (r *MissionRepository) AssignCat(ctx context.Context, missionID int, catID int) error {
+	return r.inTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		status, currentCatID, err := lockMission(ctx, tx, missionID, 0)
+		if err != nil {
+			return err
+		}
+		if currentCatID.Valid {
+			return fmt.Errorf("%w: cat %d", ErrMissionAssigned, currentCatID.Int32)
+		}
+		if err := checkTransition(status, model.MissionAssigned, catID); err != nil {
+			return err
+		}
+		if err := lockAvailableCat(ctx, tx, catID); err != nil {
+			return err
+		}
+		before, err := getMission(ctx, tx, missionID)
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, `UPDATE missions SET cat_id = $1, version = version + 1 WHERE id = $2`, catID, missionID); err != nil {
+			if isUniqueViolation(err, activeCatIndex) {
+				return fmt.Errorf("%w: cat %d", ErrCatBusy, catID)
+			}
+			return err
+		}
+		if err := openAssignment(ctx, tx, missionID, catID); err != nil {
+			return err
+		}
+		if err := transitionMission(ctx, tx, missionID, status, model.MissionAssigned, ""); err != nil {
+			return err
+		}
+		if err := auditMission(ctx, tx, actionAssign, before); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+// UnassignCat removes the cat from an assigned mission, which returns to draft,
+// or from a completed or aborted mission, which keeps its status
+func (r *MissionRepository) UnassignCat(ctx context.Context, missionID int, reason string, version int) error {
+	return r.inTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		status, catID, err := lockMission(ctx, tx, missionID, version)
+		if err != nil {
+			return err
+		}
+		if !catID.Valid {
+			return fmt.Errorf("%w: id %d", ErrMissionUnassigned, missionID)
+		}
+		if !status.Closed() {
+			if err := checkTransition(status, model.MissionDraft, 0); err != nil {
+				return err
+			}
+		}
+		before, err := getMission(ctx, tx, missionID)
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, `UPDATE missions SET cat_id = NULL, version = version + 1 WHERE id = $1`, missionID); err != nil {
+			return fmt.Errorf("unable to unassign cat: %v", err)
+		}
+		if err := closeAssignment(ctx, tx, missionID, reason); err != nil {
+			return err
+		}
+		if !status.Closed() {
+			if err := transitionMission(ctx, tx, missionID, status, model.MissionDraft, reason); err != nil {
+				return err
+			}
+		}
+		if err := auditMission(ctx, tx, actionUnassign, before); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+// ReassignCat replaces the cat of an open mission, keeping its status
+func (r *MissionRepository) ReassignCat(ctx context.Context, missionID int, catID int, reason string) error {
+	return r.inTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		status, currentCatID, err := lockMission(ctx, tx, missionID, 0)
+		if err != nil {
+			return err
+		}
+		if !currentCatID.Valid {
+			return fmt.Errorf("%w: id %d", ErrMissionUnassigned, missionID)
+		}
+		if status.Closed() {
+			return fmt.Errorf("%w: a %s mission cannot be reassigned", ErrMissionClosed, status)
+		}
+		if int(currentCatID.Int32) == catID {
+			return fmt.Errorf("%w: cat %d", ErrMissionAssigned, catID)
+		}
+		if err := lockAvailableCat(ctx, tx, catID); err != nil {
+			return err
+		}
+		before, err := getMission(ctx, tx, missionID)
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, `UPDATE missions SET cat_id = $1, version = version + 1 WHERE id = $2`, catID, missionID); err != nil {
+			if isUniqueViolation(err, activeCatIndex) {
+				return fmt.Errorf("%w: cat %d", ErrCatBusy, catID)
+			}
+			return fmt.Errorf("unable to reassign cat: %v", err)
+		}
+		if err := closeAssignment(ctx, tx, missionID, reason); err != nil {
+			return err
+		}
+		if err := openAssignment(ctx, tx, missionID, catID); err != nil {
+			return err
+		}
+		if err := auditMission(ctx, tx, actionReassign, before); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+// lockMission returns the status and cat of a mission with the expected version,
+// locking its row until the transaction ends
+func lockMission(ctx context.Context, tx *sql.Tx, missionID int, version int) (model.MissionStatus, sql.NullInt32, error) {
+	var status model.MissionStatus
+	var catID sql.NullInt32
+	var current int
+	row := tx.QueryRowContext(ctx, `SELECT status, cat_id, version FROM missions WHERE id = $1 FOR UPDATE`, missionID)
+	if err := row.Scan(&status, &catID, &current); err != nil {
+		if err == sql.ErrNoRows {
+			return "", catID, fmt.Errorf("%w: id %d", ErrMissionNotFound, missionID)
+		}
+		return "", catID, fmt.Errorf("unable to find mission: %v", err)
+	}
+	if err := checkVersion(current, version); err != nil {
+		return "", catID, err
+	}
+	return status, catID, nil
+}
+
+// auditMission records a change of a mission, reading its new state within the transaction
+func auditMission(ctx context.Context, tx *sql.Tx, action string, before model.Mission) error {
+	after, err := getMission(ctx, tx, before.ID)
 	if err != nil {
 		return err
 	}
+	return recordEvent(ctx, tx, model.AuditMission, before.ID, action, before, after)
+}
 
-	rowsAffcted, err := result.RowsAffected()
-	if rowsAffcted == 0 || err != nil {
-		return fmt.Errorf("mission is already assigned or does not exist")
+// touchMission bumps the version of a mission whose targets changed
+func touchMission(ctx context.Context, tx *sql.Tx, missionID int) error {
+	if _, err := tx.ExecContext(ctx, `UPDATE missions SET version = version + 1 WHERE id = $1`, missionID); err != nil {
+		return fmt.Errorf("unable to update mission version: %v", err)
 	}
-
 	return nil
 }
 
-func (r *MissionRepository) UpdateNotes(targetID int, notes string) error {
-	query := `
-        UPDATE targets 
-        SET notes = $1 
-        WHERE id = $2 
-        AND complete = FALSE 
-        AND mission_id IN (SELECT id FROM missions WHERE complete = FALSE)
-    `
-	result, err := r.db.Exec(query, notes, targetID)
-	if err != nil {
-		return err
+// openAssignment starts a new entry in the assignment history of a mission
+func openAssignment(ctx context.Context, tx *sql.Tx, missionID int, catID int) error {
+	query := `INSERT INTO mission_assignments (mission_id, cat_id) VALUES ($1, $2)`
+	if _, err := tx.ExecContext(ctx, query, missionID, catID); err != nil {
+		return fmt.Errorf("unable to record assignment: %v", err)
 	}
+	return nil
+}
 
-	rowsAffected, _ := result.RowsAffected()
-	if rowsAffected == 0 {
-		return fmt.Errorf("cannot update notes: mission or target is completed")
+// closeAssignment ends the current assignment of a mission with the given reason
+func closeAssignment(ctx context.Context, tx *sql.Tx, missionID int, reason string) error {
+	query := `UPDATE mission_assignments SET unassigned_at = NOW(), reason = $1 WHERE mission_id = $2 AND unassigned_at IS NULL`
+	if _, err := tx.ExecContext(ctx, query, reason, missionID); err != nil {
+		return fmt.Errorf("unable to close assignment: %v", err)
 	}
-
 	return nil
 }
 
-// Create creates a new mission with targets in the database
-func (r *MissionRepository) Create(mission *model.Mission) error {
-	tx, err := r.db.BeginTx(context.Background(), nil)
-	if err != nil {
-		return fmt.Errorf("unable to start transaction: %v", err)
+// lockAvailableCat verifies that the cat exists and has no open mission.
+// The cat row stays locked until the transaction ends, so concurrent
+// assignments of the same cat are serialized; the partial unique index
+// on missions backs this up.
+func lockAvailableCat(ctx context.Context, tx *sql.Tx, catID int) error {
+	var id int
+	if err := tx.QueryRowContext(ctx, `SELECT id FROM cats WHERE id = $1 FOR UPDATE`, catID).Scan(&id); err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("%w: id %d", ErrCatNotFound, catID)
+		}
+		return fmt.Errorf("unable to find cat: %v", err)
 	}
-	defer tx.Rollback()
+	return checkCatIdle(ctx, tx, catID)
+}
 
-	query := `INSERT INTO missions (cat_id, complete) VALUES ($1, $2) RETURNING id`
-	err = tx.QueryRow(query, mission.CatID, mission.Completed).Scan(&mission.ID)
-	if err != nil {
-		return fmt.Errorf("unable to create mission: %v", err)
+// checkCatIdle fails with ErrCatBusy if the cat has an open mission
+func checkCatIdle(ctx context.Context, tx *sql.Tx, catID int) error {
+	var busy bool
+	query := `SELECT EXISTS (SELECT 1 FROM missions WHERE cat_id = $1 AND status NOT IN ('completed', 'aborted'))`
+	if err := tx.QueryRowContext(ctx, query, catID).Scan(&busy); err != nil {
+		return fmt.Errorf("unable to check cat missions: %v", err)
+	}
+	if busy {
+		return fmt.Errorf("%w: cat %d", ErrCatBusy, catID)
 	}
+	return nil
+}
 
-	for _, target := range mission.Targets {
-		targetQuery := `INSERT INTO targets (mission_id, name, country, notes, complete) VALUES ($1, $2, $3, $4, $5)`
-		_, err := r.db.Exec(targetQuery, mission.ID, target.Name, target.Country, target.Notes, target.Complete)
+// UpdateNotes updates the notes of a target while both it and its mission are incomplete
+func (r *MissionRepository) UpdateNotes(ctx context.Context, targetID int, notes string, version int) error {
+	return r.inTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		var status model.MissionStatus
+		var missionID int
+		query := `
+        SELECT m.id, m.status
+        FROM targets t
+        JOIN missions m ON m.id = t.mission_id
+        WHERE t.id = $1
+        FOR UPDATE
+    `
+		if err := tx.QueryRowContext(ctx, query, targetID).Scan(&missionID, &status); err != nil {
+			if err == sql.ErrNoRows {
+				return fmt.Errorf("%w: id %d", ErrTargetNotFound, targetID)
+			}
+			return fmt.Errorf("unable to find target: %v", err)
+		}
+		before, err := getTarget(ctx, tx, targetID)
 		if err != nil {
-			return fmt.Errorf("unable to create target: %v", err)
+			return err
+		}
+		if status.Closed() {
+			return fmt.Errorf("%w: notes of a %s mission cannot be changed", ErrMissionClosed, status)
+		}
+		if before.Complete {
+			return fmt.Errorf("%w: its notes cannot be changed", ErrTargetCompleted)
+		}
+		if err := checkVersion(before.Version, version); err != nil {
+			return err
 		}
-	}
 
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("unable to commit transaction: %v", err)
+		if _, err := tx.ExecContext(ctx, `UPDATE targets SET notes = $1, version = version + 1 WHERE id = $2`, notes, targetID); err != nil {
+			return fmt.Errorf("unable to update notes: %v", err)
+		}
+		if err := touchMission(ctx, tx, missionID); err != nil {
+			return err
+		}
+		after := before
+		after.Notes = notes
+		after.Version++
+		if err := recordEvent(ctx, tx, model.AuditTarget, targetID, actionUpdateNotes, before, after); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+// Create creates a new mission with its targets in a single transaction and fills in
+// the IDs and versions of the mission and the targets
+func (r *MissionRepository) Create(ctx context.Context, mission *model.Mission) error {
+	if err := checkTargetCount(r.limits, len(mission.Targets)); err != nil {
+		return err
 	}
 
-	return nil
+	return r.inTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		mission.Status = model.MissionDraft
+		var catID sql.NullInt32
+		if mission.CatID != 0 {
+			mission.Status = model.MissionAssigned
+			catID = sql.NullInt32{Int32: int32(mission.CatID), Valid: true}
+		}
+		mission.Completed = false
+		mission.History = nil
+		if catID.Valid {
+			if err := lockAvailableCat(ctx, tx, mission.CatID); err != nil {
+				return err
+			}
+		}
+
+		query := `INSERT INTO missions (cat_id, status) VALUES ($1, $2) RETURNING id, version`
+		err := tx.QueryRowContext(ctx, query, catID, mission.Status).Scan(&mission.ID, &mission.Version)
+		if err != nil {
+			if isUniqueViolation(err, activeCatIndex) {
+				return fmt.Errorf("%w: cat %d", ErrCatBusy, mission.CatID)
+			}
+			return fmt.Errorf("unable to create mission: %v", err)
+		}
+		if err := recordTransition(ctx, tx, mission.ID, "", mission.Status, ""); err != nil {
+			return err
+		}
+		if catID.Valid {
+			if err := openAssignment(ctx, tx, mission.ID, mission.CatID); err != nil {
+				return err
+			}
+		}
+
+		targetQuery := `
+            INSERT INTO targets (mission_id, name, country, notes, complete)
+            VALUES ($1, $2, $3, $4, $5)
+            RETURNING id, version
+        `
+		for i := range mission.Targets {
+			target := &mission.Targets[i]
+			err := tx.QueryRowContext(ctx, targetQuery, mission.ID, target.Name, target.Country, target.Notes, target.Complete).
+				Scan(&target.ID, &target.Version)
+			if err != nil {
+				return fmt.Errorf("unable to create target: %v", err)
+			}
+		}
+		if err := recordEvent(ctx, tx, model.AuditMission, mission.ID, actionCreate, nil, mission); err != nil {
+			return err
+		}
+
+		return nil
+	})
 }
 
 // Delete deletes a mission from the database within a transaction
-func (r *MissionRepository) Delete(missionID int) error {
-	tx, err := r.db.BeginTx(context.Background(), nil)
-	if err != nil {
-		return fmt.Errorf("unable to start transaction: %v", err)
-	}
-	defer tx.Rollback()
+func (r *MissionRepository) Delete(ctx context.Context, missionID int, version int) error {
+	return r.inTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		_, catID, err := lockMission(ctx, tx, missionID, version)
+		if err != nil {
+			return err
+		}
+		if catID.Valid {
+			return fmt.Errorf("%w: unassign the cat before deleting the mission", ErrMissionAssigned)
+		}
+		before, err := getMission(ctx, tx, missionID)
+		if err != nil {
+			return err
+		}
 
-	query := `SELECT cat_id FROM missions WHERE id = $1`
-	var catID int
-	row := tx.QueryRow(query, missionID)
-	if err := row.Scan(&catID); err != nil {
-		return fmt.Errorf("unable to find mission: %v", err)
-	}
-	if catID != 0 {
-		return fmt.Errorf("mission is already assigned to a cat and cannot be deleted")
-	}
+		targetQuery := `DELETE FROM targets WHERE mission_id = $1`
+		_, err = tx.ExecContext(ctx, targetQuery, missionID)
+		if err != nil {
+			return fmt.Errorf("unable to delete targets of the mission: %v", err)
+		}
 
-	targetQuery := `DELETE FROM targets WHERE mission_id = $1`
-	_, err = tx.Exec(targetQuery, missionID)
-	if err != nil {
-		return fmt.Errorf("unable to delete targets of the mission: %v", err)
-	}
+		missionQuery := `DELETE FROM missions WHERE id = $1`
+		_, err = tx.ExecContext(ctx, missionQuery, missionID)
+		if err != nil {
+			return fmt.Errorf("unable to delete mission: %v", err)
+		}
+		if err := recordEvent(ctx, tx, model.AuditMission, missionID, actionDelete, before, nil); err != nil {
+			return err
+		}
 
-	missionQuery := `DELETE FROM missions WHERE id = $1`
-	_, err = tx.Exec(missionQuery, missionID)
-	if err != nil {
-		return fmt.Errorf("unable to delete mission: %v", err)
-	}
+		return nil
+	})
+}
 
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("unable to commit transaction: %v", err)
-	}
+// Complete marks a mission as completed. A mission with incomplete targets
+// is only completed when forced, and the reason is kept in its history.
+func (r *MissionRepository) Complete(ctx context.Context, missionID int, force bool, reason string, version int) error {
+	return r.transition(ctx, missionID, model.MissionCompleted, reason, force, version)
+}
 
-	return nil
+// Transition moves a mission to another status if the lifecycle allows it
+func (r *MissionRepository) Transition(ctx context.Context, missionID int, to model.MissionStatus, reason string) error {
+	return r.transition(ctx, missionID, to, reason, false, 0)
 }
 
-// Update marks a mission as completed
-func (r *MissionRepository) Update(missionID int) error {
-	query := `UPDATE missions SET complete = $1 WHERE id = $2`
-	commandTag, err := r.db.Exec(query, true, missionID)
-	if err != nil {
-		return fmt.Errorf("unable to update mission: %v", err)
-	}
-	rowsAffcted, err := commandTag.RowsAffected()
-	if rowsAffcted == 0 || err != nil {
-		return fmt.Errorf("no mission found with id %d", missionID)
-	}
+func (r *MissionRepository) transition(ctx context.Context, missionID int, to model.MissionStatus, reason string, force bool, version int) error {
+	return r.inTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		status, catID, err := lockMission(ctx, tx, missionID, version)
+		if err != nil {
+			return err
+		}
+		if err := checkTransition(status, to, int(catID.Int32)); err != nil {
+			return err
+		}
+		if to == model.MissionCompleted && !force {
+			open, err := countOpenTargets(ctx, tx, missionID)
+			if err != nil {
+				return err
+			}
+			if open > 0 {
+				return fmt.Errorf("%w: %d of them still open", ErrOpenTargets, open)
+			}
+		}
+		before, err := getMission(ctx, tx, missionID)
+		if err != nil {
+			return err
+		}
+		if err := transitionMission(ctx, tx, missionID, status, to, reason); err != nil {
+			return err
+		}
+		action := actionTransition
+		if to == model.MissionCompleted {
+			action = actionComplete
+		}
+		if err := auditMission(ctx, tx, action, before); err != nil {
+			return err
+		}
 
-	return nil
+		return nil
+	})
 }
 
-func (r *MissionRepository) MarkTargetAsComplete(targetID int) error {
-	query := `UPDATE targets SET complete = TRUE WHERE id = $1 AND complete = FALSE`
-	result, err := r.db.Exec(query, targetID)
-	if err != nil {
-		return err
+// countTargets counts all targets of a mission
+func countTargets(ctx context.Context, tx *sql.Tx, missionID int) (int, error) {
+	var count int
+	if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM targets WHERE mission_id = $1`, missionID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("unable to count targets: %v", err)
 	}
+	return count, nil
+}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return err
+// countOpenTargets counts the incomplete targets of a mission
+func countOpenTargets(ctx context.Context, tx *sql.Tx, missionID int) (int, error) {
+	var open int
+	query := `SELECT COUNT(*) FROM targets WHERE mission_id = $1 AND complete = FALSE`
+	if err := tx.QueryRowContext(ctx, query, missionID).Scan(&open); err != nil {
+		return 0, fmt.Errorf("unable to count open targets: %v", err)
 	}
+	return open, nil
+}
 
-	if rowsAffected == 0 {
-		return fmt.Errorf("target not found or already completed")
+// transitionMission updates the mission status and records the transition
+func transitionMission(ctx context.Context, tx *sql.Tx, missionID int, from, to model.MissionStatus, reason string) error {
+	if _, err := tx.ExecContext(ctx, `UPDATE missions SET status = $1, version = version + 1 WHERE id = $2`, to, missionID); err != nil {
+		return fmt.Errorf("unable to update mission status: %v", err)
 	}
+	return recordTransition(ctx, tx, missionID, from, to, reason)
+}
 
+// recordTransition stores a status change in the mission history
+func recordTransition(ctx context.Context, tx *sql.Tx, missionID int, from, to model.MissionStatus, reason string) error {
+	var fromStatus sql.NullString
+	if from != "" {
+		fromStatus = sql.NullString{String: string(from), Valid: true}
+	}
+	query := `INSERT INTO mission_transitions (mission_id, from_status, to_status, reason) VALUES ($1, $2, $3, $4)`
+	if _, err := tx.ExecContext(ctx, query, missionID, fromStatus, to, reason); err != nil {
+		return fmt.Errorf("unable to record mission transition: %v", err)
+	}
 	return nil
 }
 
+// MarkTargetAsComplete marks a target of an active mission as complete.
+// The first completed target moves an assigned mission to in progress and
+// completing the last open target completes the mission in the same transaction.
+// It reports whether the mission was completed.
+func (r *MissionRepository) MarkTargetAsComplete(ctx context.Context, targetID int, version int) (bool, error) {
+	missionCompleted := false
+	err := r.inTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		var missionID int
+		var status model.MissionStatus
+		query := `
+        SELECT m.id, m.status
+        FROM targets t
+        JOIN missions m ON m.id = t.mission_id
+        WHERE t.id = $1
+        FOR UPDATE OF m
+    `
+		if err := tx.QueryRowContext(ctx, query, targetID).Scan(&missionID, &status); err != nil {
+			if err == sql.ErrNoRows {
+				return fmt.Errorf("%w: id %d", ErrTargetNotFound, targetID)
+			}
+			return fmt.Errorf("unable to find target: %v", err)
+		}
+		before, err := getTarget(ctx, tx, targetID)
+		if err != nil {
+			return err
+		}
+		if before.Complete {
+			return fmt.Errorf("%w: id %d", ErrTargetCompleted, targetID)
+		}
+		if err := checkVersion(before.Version, version); err != nil {
+			return err
+		}
+		missionBefore, err := getMission(ctx, tx, missionID)
+		if err != nil {
+			return err
+		}
+
+		switch status {
+		case model.MissionInProgress:
+		case model.MissionAssigned:
+			if err := transitionMission(ctx, tx, missionID, status, model.MissionInProgress, ""); err != nil {
+				return err
+			}
+		default:
+			return &TransitionError{From: status, To: model.MissionInProgress, Reason: "targets can only be completed on assigned missions"}
+		}
+
+		if _, err := tx.ExecContext(ctx, `UPDATE targets SET complete = TRUE, version = version + 1 WHERE id = $1`, targetID); err != nil {
+			return fmt.Errorf("unable to complete target: %v", err)
+		}
+		if err := touchMission(ctx, tx, missionID); err != nil {
+			return err
+		}
+		after := before
+		after.Complete = true
+		after.Version++
+		if err := recordEvent(ctx, tx, model.AuditTarget, targetID, actionComplete, before, after); err != nil {
+			return err
+		}
+
+		open, err := countOpenTargets(ctx, tx, missionID)
+		if err != nil {
+			return err
+		}
+		missionCompleted = open == 0
+		if missionCompleted {
+			err := transitionMission(ctx, tx, missionID, model.MissionInProgress, model.MissionCompleted, "all targets completed")
+			if err != nil {
+				return err
+			}
+		}
+		// The status change of the mission is recorded as well
+		if status != model.MissionInProgress || missionCompleted {
+			action := actionTransition
+			if missionCompleted {
+				action = actionComplete
+			}
+			if err := auditMission(ctx, tx, action, missionBefore); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	return missionCompleted, err
+}
+
 // DeleteTarget deletes a target from a mission within a transaction
-func (r *MissionRepository) DeleteTarget(targetID int) error {
-	tx, err := r.db.BeginTx(context.Background(), nil)
-	if err != nil {
-		return fmt.Errorf("unable to start transaction: %v", err)
-	}
-	defer tx.Rollback()
+func (r *MissionRepository) DeleteTarget(ctx context.Context, targetID int, version int) error {
+	return r.inTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		// Lock the mission so concurrent deletes cannot take it below the minimum number of targets
+		targetQuery := `
+        SELECT t.mission_id
+        FROM targets t
+        JOIN missions m ON m.id = t.mission_id
+        WHERE t.id = $1
+        FOR UPDATE
+    `
+		var missionID int
+		row := tx.QueryRowContext(ctx, targetQuery, targetID)
+		if err := row.Scan(&missionID); err != nil {
+			if err == sql.ErrNoRows {
+				return fmt.Errorf("%w: id %d", ErrTargetNotFound, targetID)
+			}
+			return fmt.Errorf("unable to find target: %v", err)
+		}
+		before, err := getTarget(ctx, tx, targetID)
+		if err != nil {
+			return err
+		}
+		if before.Complete {
+			return fmt.Errorf("%w: it cannot be deleted", ErrTargetCompleted)
+		}
+		if err := checkVersion(before.Version, version); err != nil {
+			return err
+		}
+
+		count, err := countTargets(ctx, tx, missionID)
+		if err != nil {
+			return err
+		}
+		if err := checkTargetCount(r.limits, count-1); err != nil {
+			return err
+		}
+
+		query := `DELETE FROM targets WHERE id = $1`
+		_, err = tx.ExecContext(ctx, query, targetID)
+		if err != nil {
+			return fmt.Errorf("unable to delete target: %v", err)
+		}
+		if err := touchMission(ctx, tx, missionID); err != nil {
+			return err
+		}
+		if err := recordEvent(ctx, tx, model.AuditTarget, targetID, actionDelete, before, nil); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+// AddTarget adds a new target to an existing mission within a transaction
+func (r *MissionRepository) AddTarget(ctx context.Context, missionID int, target *model.Target) error {
+	return r.inTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		// Lock the mission so concurrent adds cannot exceed the maximum number of targets
+		missionQuery := `SELECT status FROM missions WHERE id = $1 FOR UPDATE`
+		var status model.MissionStatus
+		row := tx.QueryRowContext(ctx, missionQuery, missionID)
+		if err := row.Scan(&status); err != nil {
+			if err == sql.ErrNoRows {
+				return fmt.Errorf("%w: id %d", ErrMissionNotFound, missionID)
+			}
+			return fmt.Errorf("unable to find mission: %v", err)
+		}
+		if status.Closed() {
+			return fmt.Errorf("%w: no new targets can be added to a %s mission", ErrMissionClosed, status)
+		}
+
+		count, err := countTargets(ctx, tx, missionID)
+		if err != nil {
+			return err
+		}
+		if err := checkTargetCount(r.limits, count+1); err != nil {
+			return err
+		}
+
+		query := `INSERT INTO targets (mission_id, name, country, notes, complete) VALUES ($1, $2, $3, $4, $5) RETURNING id, version`
+		err = tx.QueryRowContext(ctx, query, missionID, target.Name, target.Country, target.Notes, target.Complete).Scan(&target.ID, &target.Version)
+		if err != nil {
+			return fmt.Errorf("unable to add target: %v", err)
+		}
+		if err := touchMission(ctx, tx, missionID); err != nil {
+			return err
+		}
+		if err := recordEvent(ctx, tx, model.AuditTarget, target.ID, actionCreate, nil, target); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
 
-	targetQuery := `SELECT complete FROM targets WHERE id = $1`
-	var isCompleted bool
-	row := tx.QueryRow(targetQuery, targetID)
-	if err := row.Scan(&isCompleted); err != nil {
-		return fmt.Errorf("unable to find target: %v", err)
+// GetAll retrieves a page of missions matching the filter ordered by ID.
+// The returned cursor is the ID to continue after, or 0 when there are no more missions.
+func (r *MissionRepository) GetAll(ctx context.Context, filter model.MissionFilter) ([]model.Mission, int, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	var conditions []string
+	var args []interface{}
+	where := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
 	}
-	if isCompleted {
-		return fmt.Errorf("target is completed and cannot be deleted")
+	where("m.id > $%d", filter.AfterID)
+	if filter.Completed != nil {
+		where("(m.status = 'completed') = $%d", *filter.Completed)
 	}
-
-	query := `DELETE FROM targets WHERE id = $1`
-	_, err = tx.Exec(query, targetID)
-	if err != nil {
-		return fmt.Errorf("unable to delete target: %v", err)
+	if filter.CatID != nil {
+		where("m.cat_id = $%d", *filter.CatID)
 	}
-
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("unable to commit transaction: %v", err)
+	if filter.Unassigned {
+		conditions = append(conditions, "m.cat_id IS NULL")
+	}
+	if filter.Country != "" {
+		where("EXISTS (SELECT 1 FROM targets t WHERE t.mission_id = m.id AND LOWER(t.country) = LOWER($%d))", filter.Country)
+	}
+	if filter.HasIncompleteTargets != nil {
+		exists := "EXISTS (SELECT 1 FROM targets t WHERE t.mission_id = m.id AND NOT t.complete)"
+		if !*filter.HasIncompleteTargets {
+			exists = "NOT " + exists
+		}
+		conditions = append(conditions, exists)
 	}
 
-	return nil
-}
+	query := "SELECT m.id, m.cat_id, m.status, m.version FROM missions m WHERE " + strings.Join(conditions, " AND ") + " ORDER BY m.id"
+	if filter.Limit > 0 {
+		// One extra row tells whether another page follows
+		args = append(args, filter.Limit+1)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
 
-// AddTarget adds a new target to an existing mission within a transaction
-func (r *MissionRepository) AddTarget(missionID int, target *model.Target) error {
-	tx, err := r.db.BeginTx(context.Background(), nil)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return fmt.Errorf("unable to start transaction: %v", err)
+		return nil, 0, fmt.Errorf("unable to retrieve missions: %v", err)
 	}
-	defer tx.Rollback()
+	defer rows.Close()
 
-	missionQuery := `SELECT complete FROM missions WHERE id = $1`
-	var isMissionCompleted bool
-	row := tx.QueryRow(missionQuery, missionID)
-	if err := row.Scan(&isMissionCompleted); err != nil {
-		return fmt.Errorf("unable to find mission: %v", err)
+	missions := []model.Mission{}
+	for rows.Next() {
+		var mission model.Mission
+		var catID sql.NullInt32
+		if err := rows.Scan(&mission.ID, &catID, &mission.Status, &mission.Version); err != nil {
+			return nil, 0, fmt.Errorf("unable to scan mission: %v", err)
+		}
+		mission.CatID = int(catID.Int32)
+		mission.Completed = mission.Status == model.MissionCompleted
+		mission.Targets = []model.Target{}
+		missions = append(missions, mission)
 	}
-	if isMissionCompleted {
-		return fmt.Errorf("mission is completed and no new targets can be added")
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("unable to retrieve missions: %v", err)
 	}
 
-	query := `INSERT INTO targets (mission_id, name, country, notes, complete) VALUES ($1, $2, $3, $4, $5)`
-	_, err = tx.Exec(query, missionID, target.Name, target.Country, target.Notes, target.Complete)
-	if err != nil {
-		return fmt.Errorf("unable to add target: %v", err)
+	nextCursor := 0
+	if filter.Limit > 0 && len(missions) > filter.Limit {
+		missions = missions[:filter.Limit]
+		nextCursor = missions[len(missions)-1].ID
 	}
 
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("unable to commit transaction: %v", err)
+	if err := loadTargets(ctx, r.db, missions); err != nil {
+		return nil, 0, err
 	}
+	return missions, nextCursor, nil
+}
 
-	return nil
+// queryer is implemented by *sql.DB and *sql.Tx
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
 }
 
-// GetAll retrieves all missions from the database
-func (r *MissionRepository) GetAll() ([]model.Mission, error) {
+// loadTargets fills in the targets of the given missions with a single query
+func loadTargets(ctx context.Context, q queryer, missions []model.Mission) error {
+	if len(missions) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(missions))
+	positions := make(map[int]int, len(missions))
+	for i, mission := range missions {
+		ids[i] = int64(mission.ID)
+		positions[mission.ID] = i
+	}
+
 	query := `
-        SELECT 
-            m.id AS mission_id, 
-            m.cat_id, 
-            m.complete, 
-            t.id AS target_id, 
-            t.name, 
-            t.country, 
-            t.notes, 
-            t.complete AS target_complete
-        FROM missions m
-        LEFT JOIN targets t ON m.id = t.mission_id
-        ORDER BY m.id, t.id
+        SELECT id, mission_id, name, country, notes, complete, version
+        FROM targets
+        WHERE mission_id = ANY($1)
+        ORDER BY id
     `
-
-	rows, err := r.db.Query(query)
+	rows, err := q.QueryContext(ctx, query, pq.Array(ids))
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve missions: %v", err)
+		return fmt.Errorf("unable to retrieve targets: %v", err)
 	}
 	defer rows.Close()
 
-	missionsMap := make(map[int]*model.Mission)
 	for rows.Next() {
-		var missionID, catID, targetID sql.NullInt32
-		var complete, targetComplete sql.NullBool
-		var name, country, notes sql.NullString
-
-		if err := rows.Scan(&missionID, &catID, &complete, &targetID, &name, &country, &notes, &targetComplete); err != nil {
-			return nil, fmt.Errorf("unable to scan row: %v", err)
+		var target model.Target
+		var missionID int
+		var notes sql.NullString
+		if err := rows.Scan(&target.ID, &missionID, &target.Name, &target.Country, &notes, &target.Complete, &target.Version); err != nil {
+			return fmt.Errorf("unable to scan target: %v", err)
 		}
+		target.Notes = notes.String
+		i := positions[missionID]
+		missions[i].Targets = append(missions[i].Targets, target)
+	}
+	return rows.Err()
+}
 
-		if _, exists := missionsMap[int(missionID.Int32)]; !exists {
-			missionsMap[int(missionID.Int32)] = &model.Mission{
-				ID:        int(missionID.Int32),
-				CatID:     int(catID.Int32),
-				Completed: complete.Bool,
-				Targets:   []model.Target{},
-			}
-		}
+// GetByID retrieves a mission with its targets and status history
+func (r *MissionRepository) GetByID(ctx context.Context, id int) (model.Mission, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
 
-		if targetID.Valid {
-			target := model.Target{
-				ID:       int(targetID.Int32),
-				Name:     name.String,
-				Country:  country.String,
-				Notes:    notes.String,
-				Complete: targetComplete.Bool,
-			}
-			missionsMap[int(missionID.Int32)].Targets = append(missionsMap[int(missionID.Int32)].Targets, target)
-		}
+	mission, err := getMission(ctx, r.db, id)
+	if err != nil {
+		return model.Mission{}, err
 	}
 
-	var missions []model.Mission
-	for _, mission := range missionsMap {
-		missions = append(missions, *mission)
+	history, err := r.history(ctx, id)
+	if err != nil {
+		return model.Mission{}, err
 	}
+	mission.History = history
 
-	return missions, nil
+	return mission, nil
 }
 
-func (r *MissionRepository) GetByID(id int) (model.Mission, error) {
+// getMission retrieves a mission with its targets but without its history
+func getMission(ctx context.Context, q queryer, id int) (model.Mission, error) {
 	var mission model.Mission
+	var catID sql.NullInt32
 
+	query := `SELECT id, cat_id, status, version FROM missions WHERE id = $1`
+	if err := q.QueryRowContext(ctx, query, id).Scan(&mission.ID, &catID, &mission.Status, &mission.Version); err != nil {
+		if err == sql.ErrNoRows {
+			return model.Mission{}, fmt.Errorf("%w: id %d", ErrMissionNotFound, id)
+		}
+		return model.Mission{}, fmt.Errorf("unable to retrieve mission: %v", err)
+	}
+	mission.CatID = int(catID.Int32)
+	mission.Completed = mission.Status == model.MissionCompleted
+	mission.Targets = []model.Target{}
+
+	missions := []model.Mission{mission}
+	if err := loadTargets(ctx, q, missions); err != nil {
+		return model.Mission{}, err
+	}
+	return missions[0], nil
+}
+
+// getTarget retrieves a single target
+func getTarget(ctx context.Context, q queryer, id int) (model.Target, error) {
+	var target model.Target
+	var notes sql.NullString
+	query := `SELECT id, name, country, notes, complete, version FROM targets WHERE id = $1`
+	err := q.QueryRowContext(ctx, query, id).Scan(&target.ID, &target.Name, &target.Country, &notes, &target.Complete, &target.Version)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return target, fmt.Errorf("%w: id %d", ErrTargetNotFound, id)
+		}
+		return target, fmt.Errorf("unable to retrieve target: %v", err)
+	}
+	target.Notes = notes.String
+	return target, nil
+}
+
+// history retrieves the status transitions of a mission in the order they happened
+func (r *MissionRepository) history(ctx context.Context, missionID int) ([]model.MissionTransition, error) {
 	query := `
-        SELECT m.id, m.cat_id, m.complete, 
-               t.id, t.name, t.country, t.notes, t.complete
-        FROM missions m
-        LEFT JOIN targets t ON m.id = t.mission_id
-        WHERE m.id = $1
+        SELECT COALESCE(from_status, ''), to_status, reason, created_at
+        FROM mission_transitions
+        WHERE mission_id = $1
+        ORDER BY id
     `
-
-	rows, err := r.db.Query(query, id)
+	rows, err := r.db.QueryContext(ctx, query, missionID)
 	if err != nil {
-		return model.Mission{}, err
+		return nil, fmt.Errorf("unable to retrieve mission history: %v", err)
 	}
 	defer rows.Close()
 
+	var history []model.MissionTransition
 	for rows.Next() {
-		var target model.Target
-		err := rows.Scan(&mission.ID, &mission.CatID, &mission.Completed,
-			&target.ID, &target.Name, &target.Country, &target.Notes, &target.Complete)
-		if err != nil {
-			return model.Mission{}, err
+		var transition model.MissionTransition
+		if err := rows.Scan(&transition.From, &transition.To, &transition.Reason, &transition.At); err != nil {
+			return nil, fmt.Errorf("unable to scan mission transition: %v", err)
 		}
+		history = append(history, transition)
+	}
+	return history, rows.Err()
+}
 
-		if target.ID != 0 {
-			mission.Targets = append(mission.Targets, target)
-		}
+// GetAssignments retrieves the assignment history matching the filter in the order the cats were assigned
+func (r *MissionRepository) GetAssignments(ctx context.Context, filter model.AssignmentFilter) ([]model.MissionAssignment, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	var conditions []string
+	var args []interface{}
+	where := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if filter.MissionID != nil {
+		where("mission_id = $%d", *filter.MissionID)
+	}
+	if filter.CatID != nil {
+		where("cat_id = $%d", *filter.CatID)
 	}
 
-	if mission.ID == 0 {
-		return model.Mission{}, fmt.Errorf("mission not found")
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = " WHERE " + strings.Join(conditions, " AND ")
 	}
 
-	return mission, nil
+	query := "SELECT mission_id, cat_id, assigned_at, unassigned_at, reason FROM mission_assignments" +
+		whereClause + " ORDER BY assigned_at, id"
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve assignments: %v", err)
+	}
+	defer rows.Close()
+
+	assignments := []model.MissionAssignment{}
+	for rows.Next() {
+		var assignment model.MissionAssignment
+		var unassignedAt sql.NullTime
+		if err := rows.Scan(&assignment.MissionID, &assignment.CatID, &assignment.AssignedAt, &unassignedAt, &assignment.Reason); err != nil {
+			return nil, fmt.Errorf("unable to scan assignment: %v", err)
+		}
+		if unassignedAt.Valid {
+			assignment.UnassignedAt = &unassignedAt.Time
+		}
+		assignments = append(assignments, assignment)
+	}
+	return assignments, rows.Err()
 }
@@ -0,0 +1,140 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"main/internal/config"
+	"main/internal/model"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+var testMissionLimits = config.Missions{MinTargets: 1, MaxTargets: 3}
+
+func newTestMission() *model.Mission {
+	return &model.Mission{Targets: []model.Target{
+		{Name: "Target A", Country: "FR"},
+		{Name: "Target B", Country: "DE"},
+		{Name: "Target C", Country: "IT"},
+	}}
+}
+
+// expectMissionInsert expects the statements Create runs before the targets of a draft mission
+func expectMissionInsert(mock sqlmock.Sqlmock, missionID int) {
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO missions").
+		WithArgs(nil, model.MissionDraft).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "version"}).AddRow(missionID, 1))
+	mock.ExpectExec("INSERT INTO mission_transitions").
+		WithArgs(missionID, nil, model.MissionDraft, "").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func expectTargetInsert(mock sqlmock.Sqlmock, missionID, targetID int, name string) *sqlmock.ExpectedQuery {
+	return mock.ExpectQuery("INSERT INTO targets").
+		WithArgs(missionID, name, sqlmock.AnyArg(), "", false).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "version"}).AddRow(targetID, 1))
+}
+
+func TestCreateMissionCommitsMissionTargetsAndAudit(t *testing.T) {
+	s, mock := newMockStore(t)
+	expectMissionInsert(mock, 7)
+	expectTargetInsert(mock, 7, 11, "Target A")
+	expectTargetInsert(mock, 7, 12, "Target B")
+	expectTargetInsert(mock, 7, 13, "Target C")
+	mock.ExpectExec("INSERT INTO audit_events").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mission := newTestMission()
+	if err := NewMissionRepository(s, testMissionLimits).Create(context.Background(), mission); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	expectationsMet(t, mock)
+
+	if mission.ID != 7 || mission.Version != 1 || mission.Status != model.MissionDraft {
+		t.Fatalf("created mission %d version %d status %s", mission.ID, mission.Version, mission.Status)
+	}
+	for i, target := range mission.Targets {
+		if target.ID != 11+i || target.Version != 1 {
+			t.Errorf("target %d has id %d and version %d", i, target.ID, target.Version)
+		}
+	}
+}
+
+func TestCreateMissionRollsBackWhenATargetFails(t *testing.T) {
+	s, mock := newMockStore(t)
+	expectMissionInsert(mock, 7)
+	expectTargetInsert(mock, 7, 11, "Target A")
+	expectTargetInsert(mock, 7, 12, "Target B")
+	mock.ExpectQuery("INSERT INTO targets").
+		WithArgs(7, "Target C", sqlmock.AnyArg(), "", false).
+		WillReturnError(errors.New("value too long"))
+	// Nothing of the mission, its transition, targets or audit event is committed
+	mock.ExpectRollback()
+
+	err := NewMissionRepository(s, testMissionLimits).Create(context.Background(), newTestMission())
+	if err == nil {
+		t.Fatal("Create succeeded although a target insert failed")
+	}
+	expectationsMet(t, mock)
+}
+
+func TestCreateMissionRollsBackWhenAuditFails(t *testing.T) {
+	s, mock := newMockStore(t)
+	expectMissionInsert(mock, 7)
+	expectTargetInsert(mock, 7, 11, "Target A")
+	expectTargetInsert(mock, 7, 12, "Target B")
+	expectTargetInsert(mock, 7, 13, "Target C")
+	mock.ExpectExec("INSERT INTO audit_events").WillReturnError(errors.New("disk full"))
+	mock.ExpectRollback()
+
+	err := NewMissionRepository(s, testMissionLimits).Create(context.Background(), newTestMission())
+	if err == nil {
+		t.Fatal("Create succeeded although the audit event failed")
+	}
+	expectationsMet(t, mock)
+}
+
+func TestCreateMissionChecksTargetLimitsBeforeTransaction(t *testing.T) {
+	s, mock := newMockStore(t)
+
+	mission := newTestMission()
+	mission.Targets = append(mission.Targets, model.Target{Name: "Target D", Country: "ES"})
+	err := NewMissionRepository(s, testMissionLimits).Create(context.Background(), mission)
+	if !errors.Is(err, ErrTargetLimit) {
+		t.Fatalf("Create returned %v, want ErrTargetLimit", err)
+	}
+	expectationsMet(t, mock)
+}
+
+// expectMissionRead expects the statements getMission runs for a mission without targets
+func expectMissionRead(mock sqlmock.Sqlmock, missionID int, catID any, status model.MissionStatus, version int) {
+	mock.ExpectQuery("SELECT id, cat_id, status, version FROM missions").
+		WithArgs(missionID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "cat_id", "status", "version"}).AddRow(missionID, catID, status, version))
+	mock.ExpectQuery("FROM targets").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "mission_id", "name", "country", "notes", "complete", "version"}))
+}
+
+func TestUnassignCatKeepsStatusOfClosedMission(t *testing.T) {
+	s, mock := newMockStore(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT status, cat_id, version FROM missions").
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "cat_id", "version"}).AddRow(model.MissionAborted, 3, 4))
+	expectMissionRead(mock, 7, 3, model.MissionAborted, 4)
+	mock.ExpectExec("UPDATE missions SET cat_id = NULL").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE mission_assignments SET unassigned_at").
+		WithArgs("closed", 7).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	// No transition is recorded: the mission stays aborted
+	expectMissionRead(mock, 7, nil, model.MissionAborted, 5)
+	mock.ExpectExec("INSERT INTO audit_events").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err := NewMissionRepository(s, testMissionLimits).UnassignCat(context.Background(), 7, "closed", 4); err != nil {
+		t.Fatalf("UnassignCat: %v", err)
+	}
+	expectationsMet(t, mock)
+}
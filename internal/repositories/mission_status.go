@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"fmt"
+	"main/internal/config"
+	"main/internal/model"
+)
+
+// missionTransitions lists the statuses a mission may move to from each status
+var missionTransitions = map[model.MissionStatus][]model.MissionStatus{
+	model.MissionDraft:      {model.MissionAssigned, model.MissionAborted},
+	model.MissionAssigned:   {model.MissionDraft, model.MissionInProgress, model.MissionAborted},
+	model.MissionInProgress: {model.MissionCompleted, model.MissionAborted},
+}
+
+// TransitionError reports a status change the mission lifecycle does not allow
+type TransitionError struct {
+	From   model.MissionStatus
+	To     model.MissionStatus
+	Reason string
+}
+
+func (e *TransitionError) Error() string {
+	msg := fmt.Sprintf("mission cannot move from %s to %s", e.From, e.To)
+	if e.Reason != "" {
+		msg += ": " + e.Reason
+	}
+	return msg
+}
+
+func (e *TransitionError) Unwrap() error {
+	return ErrIllegalTransition
+}
+
+// checkTransition verifies that a mission with the given status and cat may move to the new status
+func checkTransition(from, to model.MissionStatus, catID int) error {
+	allowed := false
+	for _, status := range missionTransitions[from] {
+		if status == to {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return &TransitionError{From: from, To: to}
+	}
+	if to == model.MissionAssigned && catID == 0 {
+		return &TransitionError{From: from, To: to, Reason: "no cat is assigned"}
+	}
+	if to == model.MissionDraft && catID != 0 {
+		return &TransitionError{From: from, To: to, Reason: "the cat must be unassigned first"}
+	}
+	return nil
+}
+
+// checkTargetCount verifies that a mission would have an allowed number of targets
+func checkTargetCount(limits config.Missions, count int) error {
+	if count < limits.MinTargets || count > limits.MaxTargets {
+		return fmt.Errorf("%w: a mission must have between %d and %d targets, it would have %d",
+			ErrTargetLimit, limits.MinTargets, limits.MaxTargets, count)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"context"
+	"main/internal/model"
+)
+
+// CatStore describes the cat persistence operations used by the handlers.
+// Like all stores, it stops waiting for the database once ctx is done.
+// Methods taking a version fail with ErrVersionMismatch unless it is 0 or the current version.
+// Changes are recorded in the audit trail on behalf of the audit.Source carried by ctx,
+// and every change of a salary, including creating and deleting a cat, in the salary history.
+type CatStore interface {
+	Create(ctx context.Context, cat *model.SpyCat) error
+	GetAll(ctx context.Context, filter model.CatFilter) ([]model.SpyCat, int, error)
+	GetByID(ctx context.Context, catID int) (*model.SpyCat, error)
+	GetByBreed(ctx context.Context, breed string) ([]model.SpyCat, error)
+	Update(ctx context.Context, cat *model.SpyCat, version int) error
+	UpdateSalary(ctx context.Context, change *model.SalaryChange, version int) error
+	Delete(ctx context.Context, catID int, version int) error
+	GetSalaryHistory(ctx context.Context, catID int) ([]model.SalaryChange, error)
+	GetSalaryChanges(ctx context.Context, from, to model.Date) ([]model.SalaryChange, error)
+}
+
+// MissionStore describes the mission and target persistence operations used by the handlers.
+// Methods taking a version fail with ErrVersionMismatch unless it is 0 or the current
+// version of the mission, or of the target for target operations.
+// Changes are recorded in the audit trail on behalf of the audit.Source carried by ctx.
+type MissionStore interface {
+	Create(ctx context.Context, mission *model.Mission) error
+	GetAll(ctx context.Context, filter model.MissionFilter) ([]model.Mission, int, error)
+	GetByID(ctx context.Context, id int) (model.Mission, error)
+	Complete(ctx context.Context, missionID int, force bool, reason string, version int) error
+	Transition(ctx context.Context, missionID int, to model.MissionStatus, reason string) error
+	Delete(ctx context.Context, missionID int, version int) error
+	AssignCat(ctx context.Context, missionID int, catID int) error
+	UnassignCat(ctx context.Context, missionID int, reason string, version int) error
+	ReassignCat(ctx context.Context, missionID int, catID int, reason string) error
+	GetAssignments(ctx context.Context, filter model.AssignmentFilter) ([]model.MissionAssignment, error)
+	AddTarget(ctx context.Context, missionID int, target *model.Target) error
+	UpdateNotes(ctx context.Context, targetID int, notes string, version int) error
+	MarkTargetAsComplete(ctx context.Context, targetID int, version int) (bool, error)
+	DeleteTarget(ctx context.Context, targetID int, version int) error
+}
+
+// AuditStore reads the audit trail written by the other stores
+type AuditStore interface {
+	GetEvents(ctx context.Context, filter model.AuditFilter) ([]model.AuditEvent, int, error)
+}
+
+// BreedStore persists the last breed list fetched from the breed source
+type BreedStore interface {
+	LoadBreeds(ctx context.Context) ([]model.Breed, model.BreedSync, error)
+	SaveBreeds(ctx context.Context, breeds []model.Breed, sync model.BreedSync) error
+	SaveBreedSync(ctx context.Context, sync model.BreedSync) error
+}
+
+var (
+	_ CatStore     = (*CatRepository)(nil)
+	_ CatStore     = (*MemoryCatRepository)(nil)
+	_ MissionStore = (*MissionRepository)(nil)
+	_ MissionStore = (*MemoryMissionRepository)(nil)
+	_ BreedStore   = (*BreedRepository)(nil)
+	_ BreedStore   = (*MemoryBreedRepository)(nil)
+	_ AuditStore   = (*AuditRepository)(nil)
+	_ AuditStore   = (*MemoryAuditRepository)(nil)
+)
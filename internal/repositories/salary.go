@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"fmt"
+	"main/internal/model"
+)
+
+// Reasons of the salary changes and ended assignments recorded on behalf of other cat changes
+const (
+	reasonInitialSalary = "initial salary"
+	reasonProfileUpdate = "profile update"
+	reasonCatDeleted    = "cat deleted"
+)
+
+// checkEffectiveDate rejects salary changes taking effect in the future or
+// before the last recorded change. A zero latest means there is no change yet.
+func checkEffectiveDate(effective, latest model.Date) error {
+	if effective.After(model.Today()) {
+		return fmt.Errorf("%w: %s is in the future", ErrInvalidEffectiveDate, effective)
+	}
+	if !latest.IsZero() && effective.Before(latest) {
+		return fmt.Errorf("%w: %s is before the last change on %s", ErrInvalidEffectiveDate, effective, latest)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+)
+
+// sortKey is a single field of a sort expression
+type sortKey struct {
+	field string
+	desc  bool
+}
+
+// parseSort parses "field,-other" into sort keys, accepting only the given fields
+func parseSort(sort string, allowed map[string]string) ([]sortKey, error) {
+	var keys []sortKey
+	for _, part := range strings.Split(sort, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		key := sortKey{field: part}
+		if strings.HasPrefix(part, "-") {
+			key = sortKey{field: part[1:], desc: true}
+		}
+		if _, ok := allowed[key.field]; !ok {
+			return nil, fmt.Errorf("%w: cannot sort by %q", ErrInvalidFilter, key.field)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// orderBy renders sort keys as an ORDER BY clause, always ending with the ID so pages are stable
+func orderBy(keys []sortKey, columns map[string]string) string {
+	var parts []string
+	for _, key := range keys {
+		part := columns[key.field]
+		if key.desc {
+			part += " DESC"
+		}
+		parts = append(parts, part)
+	}
+	parts = append(parts, "id")
+	return " ORDER BY " + strings.Join(parts, ", ")
+}
+
+// escapeLike escapes the LIKE wildcards in a user supplied search term
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
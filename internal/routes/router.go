@@ -1,24 +1,37 @@
 package routes
 
 import (
+	"main/internal/catalog"
 	"main/internal/handlers"
 	"main/internal/repositories"
+	"main/pkg/middleware"
 
 	"github.com/gin-gonic/gin"
 )
 
-func SetupRouter(catRepo *repositories.CatRepository, missionRepo *repositories.MissionRepository) *gin.Engine {
-	r := gin.Default()
+// SetupRouter registers the API routes. The pool may be nil when there is no database.
+func SetupRouter(catRepo repositories.CatStore, missionRepo repositories.MissionStore, auditRepo repositories.AuditStore,
+	breeds *catalog.BreedCatalog, countries *catalog.CountryRegistry, pool handlers.PoolStatter) *gin.Engine {
+	r := gin.New()
+	r.Use(middleware.RequestID(), gin.Logger(), gin.CustomRecovery(handlers.Recovery), handlers.AuditSource)
+	r.NoRoute(handlers.NotFound)
 
-	catHandler := handlers.NewCatHandler(catRepo)
-	missionHandler := handlers.NewMissionHandler(missionRepo)
+	catHandler := handlers.NewCatHandler(catRepo, breeds)
+	missionHandler := handlers.NewMissionHandler(missionRepo, countries)
+	breedHandler := handlers.NewBreedHandler(breeds, catRepo)
+	countryHandler := handlers.NewCountryHandler(countries)
+	auditHandler := handlers.NewAuditHandler(auditRepo)
+	payrollHandler := handlers.NewPayrollHandler(catRepo)
 
 	catRoutes := r.Group("/cat")
 	{
 		catRoutes.POST("", catHandler.CreateCat)
 		catRoutes.GET("", catHandler.GetAllCats)
 		catRoutes.GET("/:id", catHandler.GetCatByID)
+		catRoutes.PUT("/:id", catHandler.ReplaceCat)
+		catRoutes.PATCH("/:id", catHandler.PatchCat)
 		catRoutes.PUT("/:id/salary", catHandler.UpdateCatSalary)
+		catRoutes.GET("/:id/salary-history", catHandler.GetSalaryHistory)
 		catRoutes.DELETE("/:id", catHandler.DeleteCat)
 	}
 
@@ -27,14 +40,32 @@ func SetupRouter(catRepo *repositories.CatRepository, missionRepo *repositories.
 		missionRoutes.POST("", missionHandler.CreateMission)
 		missionRoutes.DELETE("/:id", missionHandler.DeleteMission)
 		missionRoutes.PUT("/:id/complete", missionHandler.CompleteMission)
+		missionRoutes.POST("/:id/transition", missionHandler.TransitionMission)
 		missionRoutes.PUT("/targets/:target_id/notes", missionHandler.UpdateTargetNotes)
 		missionRoutes.PUT("/targets/:target_id/complete", missionHandler.MarkTargetAsComplete)
 		missionRoutes.DELETE("/targets/:target_id", missionHandler.DeleteTarget)
 		missionRoutes.POST("/:id/targets", missionHandler.AddTarget)
 		missionRoutes.POST("/:id/assign-cat", missionHandler.AssignCatToMission)
+		missionRoutes.DELETE("/:id/assign-cat", missionHandler.UnassignCatFromMission)
+		missionRoutes.POST("/:id/reassign", missionHandler.ReassignMission)
+		missionRoutes.GET("/assignments", missionHandler.GetAssignments)
 		missionRoutes.GET("", missionHandler.GetAllMissions)
 		missionRoutes.GET("/:id", missionHandler.GetMissionByID)
 	}
 
+	breedRoutes := r.Group("/breeds")
+	{
+		breedRoutes.GET("", breedHandler.GetAllBreeds)
+		breedRoutes.GET("/:id", breedHandler.GetBreedByID)
+		breedRoutes.GET("/:id/cats", breedHandler.GetBreedCats)
+	}
+
+	r.GET("/countries", countryHandler.GetAllCountries)
+	r.GET("/audit", auditHandler.GetAuditEvents)
+	r.GET("/payroll", payrollHandler.GetPayroll)
+	if pool != nil {
+		r.GET("/stats/db", handlers.NewStatsHandler(pool).GetPoolStats)
+	}
+
 	return r
 }
@@ -0,0 +1,144 @@
+// Package server runs the HTTP server of the API
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"main/internal/config"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// Server serves the API over HTTP, or HTTPS when a certificate is configured
+type Server struct {
+	http            *http.Server
+	certFile        string
+	keyFile         string
+	shutdownTimeout time.Duration
+}
+
+// New creates a server for the handler, loading the client CAs for mutual TLS if configured
+func New(cfg config.HTTP, handler http.Handler) (*Server, error) {
+	s := &Server{
+		http: &http.Server{
+			Addr:              cfg.Addr,
+			Handler:           handler,
+			ReadTimeout:       cfg.ReadTimeout,
+			ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+			WriteTimeout:      cfg.WriteTimeout,
+			IdleTimeout:       cfg.IdleTimeout,
+			MaxHeaderBytes:    cfg.MaxHeaderBytes,
+		},
+		certFile:        cfg.TLSCertFile,
+		keyFile:         cfg.TLSKeyFile,
+		shutdownTimeout: cfg.ShutdownTimeout,
+	}
+
+	if cfg.TLSCertFile != "" {
+		s.http.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	if cfg.ClientCAFile != "" {
+		pem, err := os.ReadFile(cfg.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read client CA file: %v", err)
+		}
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in client CA file %s", cfg.ClientCAFile)
+		}
+		s.http.TLSConfig.ClientCAs = clientCAs
+		s.http.TLSConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return s, nil
+}
+
+// TLS reports whether the server uses HTTPS
+func (s *Server) TLS() bool {
+	return s.certFile != ""
+}
+
+// Run serves requests and runs the background workers until ctx is done.
+// It then stops accepting connections, waits up to the shutdown timeout for
+// the requests in flight, stops the workers and closes the closers, in that
+// order, so no request loses the resources it is using. It returns an error
+// only when the server could not serve.
+func (s *Server) Run(ctx context.Context, workers []func(context.Context), closers ...io.Closer) error {
+	ln, err := net.Listen("tcp", s.http.Addr)
+	if err != nil {
+		stop(nil, closers)
+		return err
+	}
+	return s.run(ctx, ln, workers, closers)
+}
+
+func (s *Server) run(ctx context.Context, ln net.Listener, workers []func(context.Context), closers []io.Closer) error {
+	log.Printf("Listening on %s (TLS: %t)", ln.Addr(), s.TLS())
+	served := make(chan error, 1)
+	go func() {
+		if s.TLS() {
+			served <- s.http.ServeTLS(ln, s.certFile, s.keyFile)
+		} else {
+			served <- s.http.Serve(ln)
+		}
+	}()
+
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	for _, worker := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			worker(workersCtx)
+		}()
+	}
+
+	var err error
+	select {
+	case err = <-served:
+	case <-ctx.Done():
+		err = s.shutdown(served)
+	}
+
+	stop(func() {
+		stopWorkers()
+		wg.Wait()
+	}, closers)
+	return err
+}
+
+// shutdown drains the server and returns the error it stopped serving with, if any
+func (s *Server) shutdown(served <-chan error) error {
+	log.Printf("Shutting down, waiting up to %s for in-flight requests", s.shutdownTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+	defer cancel()
+	if err := s.http.Shutdown(ctx); err != nil {
+		log.Printf("Server shutdown incomplete, closing remaining connections: %v", err)
+		s.http.Close()
+	}
+
+	if err := <-served; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// stop stops the workers, then closes the closers
+func stop(stopWorkers func(), closers []io.Closer) {
+	if stopWorkers != nil {
+		stopWorkers()
+	}
+	for _, closer := range closers {
+		if err := closer.Close(); err != nil {
+			log.Printf("Can`t close %T: %v", closer, err)
+		}
+	}
+	log.Println("Server stopped")
+}
@@ -0,0 +1,196 @@
+package server
+
+import (
+	"context"
+	"io"
+	"main/internal/config"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// events records the order in which the parts of the server stop
+type events struct {
+	mu   sync.Mutex
+	list []string
+}
+
+func (e *events) add(event string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.list = append(e.list, event)
+}
+
+func (e *events) get() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]string(nil), e.list...)
+}
+
+// closerFunc turns a function into an io.Closer
+type closerFunc func() error
+
+func (f closerFunc) Close() error {
+	return f()
+}
+
+// startServer runs a server with a worker and a closer recording their stop on a random local port
+func startServer(t *testing.T, shutdownTimeout time.Duration, handler http.Handler, stops *events) (string, context.CancelFunc, <-chan error) {
+	t.Helper()
+	srv, err := New(config.HTTP{ShutdownTimeout: shutdownTimeout}, handler)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+
+	worker := func(ctx context.Context) {
+		<-ctx.Done()
+		stops.add("worker stopped")
+	}
+	db := closerFunc(func() error {
+		stops.add("db closed")
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- srv.run(ctx, ln, []func(context.Context){worker}, []io.Closer{db})
+	}()
+	t.Cleanup(cancel)
+	return ln.Addr().String(), cancel, done
+}
+
+func TestRunDrainsInFlightRequests(t *testing.T) {
+	stops := &events{}
+	started := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(300 * time.Millisecond)
+		stops.add("request finished")
+		io.WriteString(w, "done")
+	})
+	addr, cancel, done := startServer(t, 5*time.Second, handler, stops)
+
+	type result struct {
+		status int
+		body   string
+		err    error
+	}
+	responses := make(chan result, 1)
+	go func() {
+		resp, err := http.Get("http://" + addr + "/slow")
+		if err != nil {
+			responses <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		responses <- result{status: resp.StatusCode, body: string(body), err: err}
+	}()
+
+	<-started
+	cancel()
+
+	// The listener closes at once while the slow request is still running
+	refused := false
+	for deadline := time.Now().Add(200 * time.Millisecond); time.Now().Before(deadline); time.Sleep(5 * time.Millisecond) {
+		conn, err := net.Dial("tcp", addr)
+		if err != nil {
+			refused = true
+			break
+		}
+		conn.Close()
+	}
+	if !refused {
+		t.Fatal("new connections are still accepted after shutdown started")
+	}
+	if got := stops.get(); len(got) != 0 {
+		t.Fatalf("%v before the in-flight request finished", got)
+	}
+
+	res := <-responses
+	if res.err != nil || res.status != http.StatusOK || res.body != "done" {
+		t.Fatalf("in-flight request got status %d, body %q, error %v", res.status, res.body, res.err)
+	}
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("run returned %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("run did not return after the requests drained")
+	}
+
+	want := []string{"request finished", "worker stopped", "db closed"}
+	got := stops.get()
+	if len(got) != len(want) {
+		t.Fatalf("stopped in order %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("stopped in order %v, want %v", got, want)
+		}
+	}
+}
+
+func TestRunGivesUpAfterShutdownTimeout(t *testing.T) {
+	stops := &events{}
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+	})
+	addr, cancel, done := startServer(t, 50*time.Millisecond, handler, stops)
+
+	go func() {
+		resp, err := http.Get("http://" + addr + "/stuck")
+		if err == nil {
+			resp.Body.Close()
+		}
+	}()
+	<-started
+
+	start := time.Now()
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("run returned %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("run waited for a stuck request beyond the shutdown timeout")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("run returned after %s, want about the shutdown timeout", elapsed)
+	}
+	if got := stops.get(); len(got) != 2 || got[0] != "worker stopped" || got[1] != "db closed" {
+		t.Fatalf("stopped %v, want the worker stopped and the db closed", got)
+	}
+}
+
+func TestRunReportsServeFailure(t *testing.T) {
+	srv, err := New(config.HTTP{Addr: "127.0.0.1:-1"}, http.NotFoundHandler())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	closed := false
+	db := closerFunc(func() error {
+		closed = true
+		return nil
+	})
+	if err := srv.Run(context.Background(), nil, db); err == nil {
+		t.Fatal("Run succeeded on an invalid address")
+	}
+	if !closed {
+		t.Fatal("Run did not close the db after failing to listen")
+	}
+}
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS cats (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    years_of_experience INT NOT NULL,
+    breed VARCHAR(255) NOT NULL,
+    salary DECIMAL(10, 2) NOT NULL
+);
@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS missions (
+    id SERIAL PRIMARY KEY,
+    cat_id INT REFERENCES cats(id) ON DELETE SET NULL,
+    complete BOOLEAN NOT NULL DEFAULT FALSE
+);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS targets (
+    id SERIAL PRIMARY KEY,
+    mission_id INT REFERENCES missions(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    country VARCHAR(255) NOT NULL,
+    notes TEXT,
+    complete BOOLEAN NOT NULL DEFAULT FALSE
+);
@@ -0,0 +1,3 @@
+-- Intentionally a no-op: the seed rows are matched only by name and can no
+-- longer be told apart from rows created through the API, so reverting the
+-- seed leaves the data in place instead of deleting anything users own.
@@ -0,0 +1,32 @@
+-- Seed rows are only inserted into an empty database, so databases that were
+-- created from the old init.sql keep their data untouched.
+INSERT INTO cats (name, years_of_experience, breed, salary)
+SELECT v.name, v.years_of_experience, v.breed, v.salary
+FROM (VALUES
+    ('Whiskers', 5, 'Persian', 3000.00),
+    ('Shadow', 3, 'Maine Coon', 2500.00),
+    ('Luna', 4, 'Siamese', 2700.00)
+) AS v (name, years_of_experience, breed, salary)
+WHERE NOT EXISTS (SELECT 1 FROM cats);
+
+INSERT INTO missions (cat_id, complete)
+SELECT c.id, v.complete
+FROM (VALUES
+    ('Whiskers', FALSE),
+    ('Shadow', FALSE),
+    ('Luna', TRUE)
+) AS v (cat_name, complete)
+JOIN cats c ON c.name = v.cat_name
+WHERE NOT EXISTS (SELECT 1 FROM missions);
+
+INSERT INTO targets (mission_id, name, country, notes, complete)
+SELECT m.id, v.name, v.country, v.notes, v.complete
+FROM (VALUES
+    ('Whiskers', 'Target A', 'USA', 'Initial data collected', FALSE),
+    ('Whiskers', 'Target B', 'Canada', 'Observing behavior', FALSE),
+    ('Shadow', 'Target C', 'Germany', 'Planning next steps', FALSE),
+    ('Luna', 'Target D', 'France', 'Completed initial phase', TRUE)
+) AS v (cat_name, name, country, notes, complete)
+JOIN cats c ON c.name = v.cat_name
+JOIN missions m ON m.cat_id = c.id
+WHERE NOT EXISTS (SELECT 1 FROM targets);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS breed_sync;
+DROP TABLE IF EXISTS breeds;
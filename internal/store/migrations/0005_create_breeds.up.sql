@@ -0,0 +1,14 @@
+CREATE TABLE breeds (
+    id VARCHAR(32) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    origin VARCHAR(255) NOT NULL DEFAULT '',
+    temperament TEXT NOT NULL DEFAULT '',
+    alt_names TEXT[] NOT NULL DEFAULT '{}'
+);
+
+-- breed_sync holds a single row describing the last successful fetch of the breed list
+CREATE TABLE breed_sync (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    etag TEXT NOT NULL DEFAULT '',
+    fetched_at TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS targets_mission_id_idx;
+DROP INDEX IF EXISTS missions_cat_id_idx;
@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS missions_cat_id_idx ON missions (cat_id);
+CREATE INDEX IF NOT EXISTS targets_mission_id_idx ON targets (mission_id);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS mission_transitions;
+
+ALTER TABLE missions ADD COLUMN complete BOOLEAN NOT NULL DEFAULT FALSE;
+UPDATE missions SET complete = (status = 'completed');
+ALTER TABLE missions DROP COLUMN status;
@@ -0,0 +1,26 @@
+ALTER TABLE missions
+    ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'draft'
+    CHECK (status IN ('draft', 'assigned', 'in_progress', 'completed', 'aborted'));
+
+UPDATE missions SET status = CASE
+    WHEN complete THEN 'completed'
+    WHEN cat_id IS NOT NULL THEN 'assigned'
+    ELSE 'draft'
+END;
+
+ALTER TABLE missions DROP COLUMN complete;
+
+CREATE TABLE mission_transitions (
+    id SERIAL PRIMARY KEY,
+    mission_id INT NOT NULL REFERENCES missions(id) ON DELETE CASCADE,
+    from_status VARCHAR(16),
+    to_status VARCHAR(16) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX mission_transitions_mission_id_idx ON mission_transitions (mission_id);
+
+-- Existing missions start their history with the status they were migrated to
+INSERT INTO mission_transitions (mission_id, from_status, to_status, reason)
+SELECT id, NULL, status, 'migrated' FROM missions;
@@ -0,0 +1,4 @@
+-- A cat may work on a single open mission at a time. This fails if a cat is
+-- already assigned to several open missions; reassign or abort them first.
+CREATE UNIQUE INDEX missions_one_active_per_cat ON missions (cat_id)
+    WHERE cat_id IS NOT NULL AND status NOT IN ('completed', 'aborted');
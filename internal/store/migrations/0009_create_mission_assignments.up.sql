@@ -0,0 +1,21 @@
+-- cat_id has no foreign key so the history outlives deleted cats
+CREATE TABLE mission_assignments (
+    id SERIAL PRIMARY KEY,
+    mission_id INT NOT NULL REFERENCES missions(id) ON DELETE CASCADE,
+    cat_id INT NOT NULL,
+    assigned_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    unassigned_at TIMESTAMPTZ,
+    reason TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX mission_assignments_mission_id_idx ON mission_assignments (mission_id);
+CREATE INDEX mission_assignments_cat_id_idx ON mission_assignments (cat_id);
+
+-- Existing assignments start when their mission was first assigned, if known
+INSERT INTO mission_assignments (mission_id, cat_id, assigned_at)
+SELECT m.id, m.cat_id, COALESCE(
+    (SELECT MIN(t.created_at) FROM mission_transitions t WHERE t.mission_id = m.id AND t.to_status = 'assigned'),
+    NOW()
+)
+FROM missions m
+WHERE m.cat_id IS NOT NULL;
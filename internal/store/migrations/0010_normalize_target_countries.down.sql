@@ -0,0 +1 @@
+-- The original spellings of the countries are not kept, so the codes stay.
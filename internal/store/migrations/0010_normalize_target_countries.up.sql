@@ -0,0 +1,951 @@
+-- Normalizes target countries to ISO 3166-1 alpha-2 codes. Names, alpha-3
+-- codes and common aliases from the bundled country registry are matched
+-- ignoring case and extra spaces; unknown values are left untouched.
+UPDATE targets t SET country = c.alpha2
+FROM (VALUES
+    ('af', 'AF'),
+    ('afg', 'AF'),
+    ('afghanistan', 'AF'),
+    ('islamic republic of afghanistan', 'AF'),
+    ('al', 'AL'),
+    ('alb', 'AL'),
+    ('albania', 'AL'),
+    ('republic of albania', 'AL'),
+    ('dz', 'DZ'),
+    ('dza', 'DZ'),
+    ('algeria', 'DZ'),
+    ('people''s democratic republic of algeria', 'DZ'),
+    ('as', 'AS'),
+    ('asm', 'AS'),
+    ('american samoa', 'AS'),
+    ('ad', 'AD'),
+    ('and', 'AD'),
+    ('andorra', 'AD'),
+    ('principality of andorra', 'AD'),
+    ('ao', 'AO'),
+    ('ago', 'AO'),
+    ('angola', 'AO'),
+    ('republic of angola', 'AO'),
+    ('ai', 'AI'),
+    ('aia', 'AI'),
+    ('anguilla', 'AI'),
+    ('aq', 'AQ'),
+    ('ata', 'AQ'),
+    ('antarctica', 'AQ'),
+    ('ag', 'AG'),
+    ('atg', 'AG'),
+    ('antigua and barbuda', 'AG'),
+    ('ar', 'AR'),
+    ('arg', 'AR'),
+    ('argentina', 'AR'),
+    ('argentine republic', 'AR'),
+    ('am', 'AM'),
+    ('arm', 'AM'),
+    ('armenia', 'AM'),
+    ('republic of armenia', 'AM'),
+    ('aw', 'AW'),
+    ('abw', 'AW'),
+    ('aruba', 'AW'),
+    ('au', 'AU'),
+    ('aus', 'AU'),
+    ('australia', 'AU'),
+    ('at', 'AT'),
+    ('aut', 'AT'),
+    ('austria', 'AT'),
+    ('republic of austria', 'AT'),
+    ('az', 'AZ'),
+    ('aze', 'AZ'),
+    ('azerbaijan', 'AZ'),
+    ('republic of azerbaijan', 'AZ'),
+    ('bs', 'BS'),
+    ('bhs', 'BS'),
+    ('bahamas', 'BS'),
+    ('commonwealth of the bahamas', 'BS'),
+    ('bh', 'BH'),
+    ('bhr', 'BH'),
+    ('bahrain', 'BH'),
+    ('kingdom of bahrain', 'BH'),
+    ('bd', 'BD'),
+    ('bgd', 'BD'),
+    ('bangladesh', 'BD'),
+    ('people''s republic of bangladesh', 'BD'),
+    ('bb', 'BB'),
+    ('brb', 'BB'),
+    ('barbados', 'BB'),
+    ('by', 'BY'),
+    ('blr', 'BY'),
+    ('belarus', 'BY'),
+    ('republic of belarus', 'BY'),
+    ('be', 'BE'),
+    ('bel', 'BE'),
+    ('belgium', 'BE'),
+    ('kingdom of belgium', 'BE'),
+    ('bz', 'BZ'),
+    ('blz', 'BZ'),
+    ('belize', 'BZ'),
+    ('bj', 'BJ'),
+    ('ben', 'BJ'),
+    ('benin', 'BJ'),
+    ('republic of benin', 'BJ'),
+    ('bm', 'BM'),
+    ('bmu', 'BM'),
+    ('bermuda', 'BM'),
+    ('bt', 'BT'),
+    ('btn', 'BT'),
+    ('bhutan', 'BT'),
+    ('kingdom of bhutan', 'BT'),
+    ('bo', 'BO'),
+    ('bol', 'BO'),
+    ('bolivia', 'BO'),
+    ('bolivia, plurinational state of', 'BO'),
+    ('plurinational state of bolivia', 'BO'),
+    ('bq', 'BQ'),
+    ('bes', 'BQ'),
+    ('bonaire, sint eustatius and saba', 'BQ'),
+    ('ba', 'BA'),
+    ('bih', 'BA'),
+    ('bosnia and herzegovina', 'BA'),
+    ('republic of bosnia and herzegovina', 'BA'),
+    ('bw', 'BW'),
+    ('bwa', 'BW'),
+    ('botswana', 'BW'),
+    ('republic of botswana', 'BW'),
+    ('bv', 'BV'),
+    ('bvt', 'BV'),
+    ('bouvet island', 'BV'),
+    ('br', 'BR'),
+    ('bra', 'BR'),
+    ('brazil', 'BR'),
+    ('federative republic of brazil', 'BR'),
+    ('io', 'IO'),
+    ('iot', 'IO'),
+    ('british indian ocean territory', 'IO'),
+    ('bn', 'BN'),
+    ('brn', 'BN'),
+    ('brunei darussalam', 'BN'),
+    ('bg', 'BG'),
+    ('bgr', 'BG'),
+    ('bulgaria', 'BG'),
+    ('republic of bulgaria', 'BG'),
+    ('bf', 'BF'),
+    ('bfa', 'BF'),
+    ('burkina faso', 'BF'),
+    ('bi', 'BI'),
+    ('bdi', 'BI'),
+    ('burundi', 'BI'),
+    ('republic of burundi', 'BI'),
+    ('cv', 'CV'),
+    ('cpv', 'CV'),
+    ('cabo verde', 'CV'),
+    ('republic of cabo verde', 'CV'),
+    ('cape verde', 'CV'),
+    ('kh', 'KH'),
+    ('khm', 'KH'),
+    ('cambodia', 'KH'),
+    ('kingdom of cambodia', 'KH'),
+    ('cm', 'CM'),
+    ('cmr', 'CM'),
+    ('cameroon', 'CM'),
+    ('republic of cameroon', 'CM'),
+    ('ca', 'CA'),
+    ('can', 'CA'),
+    ('canada', 'CA'),
+    ('ky', 'KY'),
+    ('cym', 'KY'),
+    ('cayman islands', 'KY'),
+    ('cf', 'CF'),
+    ('caf', 'CF'),
+    ('central african republic', 'CF'),
+    ('td', 'TD'),
+    ('tcd', 'TD'),
+    ('chad', 'TD'),
+    ('republic of chad', 'TD'),
+    ('cl', 'CL'),
+    ('chl', 'CL'),
+    ('chile', 'CL'),
+    ('republic of chile', 'CL'),
+    ('cn', 'CN'),
+    ('chn', 'CN'),
+    ('china', 'CN'),
+    ('people''s republic of china', 'CN'),
+    ('cx', 'CX'),
+    ('cxr', 'CX'),
+    ('christmas island', 'CX'),
+    ('cc', 'CC'),
+    ('cck', 'CC'),
+    ('cocos (keeling) islands', 'CC'),
+    ('co', 'CO'),
+    ('col', 'CO'),
+    ('colombia', 'CO'),
+    ('republic of colombia', 'CO'),
+    ('km', 'KM'),
+    ('com', 'KM'),
+    ('comoros', 'KM'),
+    ('union of the comoros', 'KM'),
+    ('cg', 'CG'),
+    ('cog', 'CG'),
+    ('congo', 'CG'),
+    ('republic of the congo', 'CG'),
+    ('cd', 'CD'),
+    ('cod', 'CD'),
+    ('congo, the democratic republic of the', 'CD'),
+    ('ck', 'CK'),
+    ('cok', 'CK'),
+    ('cook islands', 'CK'),
+    ('cr', 'CR'),
+    ('cri', 'CR'),
+    ('costa rica', 'CR'),
+    ('republic of costa rica', 'CR'),
+    ('hr', 'HR'),
+    ('hrv', 'HR'),
+    ('croatia', 'HR'),
+    ('republic of croatia', 'HR'),
+    ('cu', 'CU'),
+    ('cub', 'CU'),
+    ('cuba', 'CU'),
+    ('republic of cuba', 'CU'),
+    ('cw', 'CW'),
+    ('cuw', 'CW'),
+    ('curaçao', 'CW'),
+    ('cy', 'CY'),
+    ('cyp', 'CY'),
+    ('cyprus', 'CY'),
+    ('republic of cyprus', 'CY'),
+    ('cz', 'CZ'),
+    ('cze', 'CZ'),
+    ('czechia', 'CZ'),
+    ('czech republic', 'CZ'),
+    ('ci', 'CI'),
+    ('civ', 'CI'),
+    ('côte d''ivoire', 'CI'),
+    ('republic of côte d''ivoire', 'CI'),
+    ('cote d''ivoire', 'CI'),
+    ('ivory coast', 'CI'),
+    ('dk', 'DK'),
+    ('dnk', 'DK'),
+    ('denmark', 'DK'),
+    ('kingdom of denmark', 'DK'),
+    ('dj', 'DJ'),
+    ('dji', 'DJ'),
+    ('djibouti', 'DJ'),
+    ('republic of djibouti', 'DJ'),
+    ('dm', 'DM'),
+    ('dma', 'DM'),
+    ('dominica', 'DM'),
+    ('commonwealth of dominica', 'DM'),
+    ('do', 'DO'),
+    ('dom', 'DO'),
+    ('dominican republic', 'DO'),
+    ('ec', 'EC'),
+    ('ecu', 'EC'),
+    ('ecuador', 'EC'),
+    ('republic of ecuador', 'EC'),
+    ('eg', 'EG'),
+    ('egy', 'EG'),
+    ('egypt', 'EG'),
+    ('arab republic of egypt', 'EG'),
+    ('sv', 'SV'),
+    ('slv', 'SV'),
+    ('el salvador', 'SV'),
+    ('republic of el salvador', 'SV'),
+    ('gq', 'GQ'),
+    ('gnq', 'GQ'),
+    ('equatorial guinea', 'GQ'),
+    ('republic of equatorial guinea', 'GQ'),
+    ('er', 'ER'),
+    ('eri', 'ER'),
+    ('eritrea', 'ER'),
+    ('the state of eritrea', 'ER'),
+    ('ee', 'EE'),
+    ('est', 'EE'),
+    ('estonia', 'EE'),
+    ('republic of estonia', 'EE'),
+    ('sz', 'SZ'),
+    ('swz', 'SZ'),
+    ('eswatini', 'SZ'),
+    ('kingdom of eswatini', 'SZ'),
+    ('swaziland', 'SZ'),
+    ('et', 'ET'),
+    ('eth', 'ET'),
+    ('ethiopia', 'ET'),
+    ('federal democratic republic of ethiopia', 'ET'),
+    ('fk', 'FK'),
+    ('flk', 'FK'),
+    ('falkland islands (malvinas)', 'FK'),
+    ('fo', 'FO'),
+    ('fro', 'FO'),
+    ('faroe islands', 'FO'),
+    ('fj', 'FJ'),
+    ('fji', 'FJ'),
+    ('fiji', 'FJ'),
+    ('republic of fiji', 'FJ'),
+    ('fi', 'FI'),
+    ('fin', 'FI'),
+    ('finland', 'FI'),
+    ('republic of finland', 'FI'),
+    ('fr', 'FR'),
+    ('fra', 'FR'),
+    ('france', 'FR'),
+    ('french republic', 'FR'),
+    ('gf', 'GF'),
+    ('guf', 'GF'),
+    ('french guiana', 'GF'),
+    ('pf', 'PF'),
+    ('pyf', 'PF'),
+    ('french polynesia', 'PF'),
+    ('tf', 'TF'),
+    ('atf', 'TF'),
+    ('french southern territories', 'TF'),
+    ('ga', 'GA'),
+    ('gab', 'GA'),
+    ('gabon', 'GA'),
+    ('gabonese republic', 'GA'),
+    ('gm', 'GM'),
+    ('gmb', 'GM'),
+    ('gambia', 'GM'),
+    ('republic of the gambia', 'GM'),
+    ('ge', 'GE'),
+    ('geo', 'GE'),
+    ('georgia', 'GE'),
+    ('de', 'DE'),
+    ('deu', 'DE'),
+    ('germany', 'DE'),
+    ('federal republic of germany', 'DE'),
+    ('gh', 'GH'),
+    ('gha', 'GH'),
+    ('ghana', 'GH'),
+    ('republic of ghana', 'GH'),
+    ('gi', 'GI'),
+    ('gib', 'GI'),
+    ('gibraltar', 'GI'),
+    ('gr', 'GR'),
+    ('grc', 'GR'),
+    ('greece', 'GR'),
+    ('hellenic republic', 'GR'),
+    ('gl', 'GL'),
+    ('grl', 'GL'),
+    ('greenland', 'GL'),
+    ('gd', 'GD'),
+    ('grd', 'GD'),
+    ('grenada', 'GD'),
+    ('gp', 'GP'),
+    ('glp', 'GP'),
+    ('guadeloupe', 'GP'),
+    ('gu', 'GU'),
+    ('gum', 'GU'),
+    ('guam', 'GU'),
+    ('gt', 'GT'),
+    ('gtm', 'GT'),
+    ('guatemala', 'GT'),
+    ('republic of guatemala', 'GT'),
+    ('gg', 'GG'),
+    ('ggy', 'GG'),
+    ('guernsey', 'GG'),
+    ('gn', 'GN'),
+    ('gin', 'GN'),
+    ('guinea', 'GN'),
+    ('republic of guinea', 'GN'),
+    ('gw', 'GW'),
+    ('gnb', 'GW'),
+    ('guinea-bissau', 'GW'),
+    ('republic of guinea-bissau', 'GW'),
+    ('gy', 'GY'),
+    ('guy', 'GY'),
+    ('guyana', 'GY'),
+    ('republic of guyana', 'GY'),
+    ('ht', 'HT'),
+    ('hti', 'HT'),
+    ('haiti', 'HT'),
+    ('republic of haiti', 'HT'),
+    ('hm', 'HM'),
+    ('hmd', 'HM'),
+    ('heard island and mcdonald islands', 'HM'),
+    ('va', 'VA'),
+    ('vat', 'VA'),
+    ('holy see (vatican city state)', 'VA'),
+    ('vatican', 'VA'),
+    ('hn', 'HN'),
+    ('hnd', 'HN'),
+    ('honduras', 'HN'),
+    ('republic of honduras', 'HN'),
+    ('hk', 'HK'),
+    ('hkg', 'HK'),
+    ('hong kong', 'HK'),
+    ('hong kong special administrative region of china', 'HK'),
+    ('hu', 'HU'),
+    ('hun', 'HU'),
+    ('hungary', 'HU'),
+    ('is', 'IS'),
+    ('isl', 'IS'),
+    ('iceland', 'IS'),
+    ('republic of iceland', 'IS'),
+    ('in', 'IN'),
+    ('ind', 'IN'),
+    ('india', 'IN'),
+    ('republic of india', 'IN'),
+    ('id', 'ID'),
+    ('idn', 'ID'),
+    ('indonesia', 'ID'),
+    ('republic of indonesia', 'ID'),
+    ('ir', 'IR'),
+    ('irn', 'IR'),
+    ('iran', 'IR'),
+    ('iran, islamic republic of', 'IR'),
+    ('islamic republic of iran', 'IR'),
+    ('iq', 'IQ'),
+    ('irq', 'IQ'),
+    ('iraq', 'IQ'),
+    ('republic of iraq', 'IQ'),
+    ('ie', 'IE'),
+    ('irl', 'IE'),
+    ('ireland', 'IE'),
+    ('im', 'IM'),
+    ('imn', 'IM'),
+    ('isle of man', 'IM'),
+    ('il', 'IL'),
+    ('isr', 'IL'),
+    ('israel', 'IL'),
+    ('state of israel', 'IL'),
+    ('it', 'IT'),
+    ('ita', 'IT'),
+    ('italy', 'IT'),
+    ('italian republic', 'IT'),
+    ('jm', 'JM'),
+    ('jam', 'JM'),
+    ('jamaica', 'JM'),
+    ('jp', 'JP'),
+    ('jpn', 'JP'),
+    ('japan', 'JP'),
+    ('je', 'JE'),
+    ('jey', 'JE'),
+    ('jersey', 'JE'),
+    ('jo', 'JO'),
+    ('jor', 'JO'),
+    ('jordan', 'JO'),
+    ('hashemite kingdom of jordan', 'JO'),
+    ('kz', 'KZ'),
+    ('kaz', 'KZ'),
+    ('kazakhstan', 'KZ'),
+    ('republic of kazakhstan', 'KZ'),
+    ('ke', 'KE'),
+    ('ken', 'KE'),
+    ('kenya', 'KE'),
+    ('republic of kenya', 'KE'),
+    ('ki', 'KI'),
+    ('kir', 'KI'),
+    ('kiribati', 'KI'),
+    ('republic of kiribati', 'KI'),
+    ('kw', 'KW'),
+    ('kwt', 'KW'),
+    ('kuwait', 'KW'),
+    ('state of kuwait', 'KW'),
+    ('kg', 'KG'),
+    ('kgz', 'KG'),
+    ('kyrgyzstan', 'KG'),
+    ('kyrgyz republic', 'KG'),
+    ('la', 'LA'),
+    ('lao', 'LA'),
+    ('laos', 'LA'),
+    ('lao people''s democratic republic', 'LA'),
+    ('lv', 'LV'),
+    ('lva', 'LV'),
+    ('latvia', 'LV'),
+    ('republic of latvia', 'LV'),
+    ('lb', 'LB'),
+    ('lbn', 'LB'),
+    ('lebanon', 'LB'),
+    ('lebanese republic', 'LB'),
+    ('ls', 'LS'),
+    ('lso', 'LS'),
+    ('lesotho', 'LS'),
+    ('kingdom of lesotho', 'LS'),
+    ('lr', 'LR'),
+    ('lbr', 'LR'),
+    ('liberia', 'LR'),
+    ('republic of liberia', 'LR'),
+    ('ly', 'LY'),
+    ('lby', 'LY'),
+    ('libya', 'LY'),
+    ('li', 'LI'),
+    ('lie', 'LI'),
+    ('liechtenstein', 'LI'),
+    ('principality of liechtenstein', 'LI'),
+    ('lt', 'LT'),
+    ('ltu', 'LT'),
+    ('lithuania', 'LT'),
+    ('republic of lithuania', 'LT'),
+    ('lu', 'LU'),
+    ('lux', 'LU'),
+    ('luxembourg', 'LU'),
+    ('grand duchy of luxembourg', 'LU'),
+    ('mo', 'MO'),
+    ('mac', 'MO'),
+    ('macao', 'MO'),
+    ('macao special administrative region of china', 'MO'),
+    ('mg', 'MG'),
+    ('mdg', 'MG'),
+    ('madagascar', 'MG'),
+    ('republic of madagascar', 'MG'),
+    ('mw', 'MW'),
+    ('mwi', 'MW'),
+    ('malawi', 'MW'),
+    ('republic of malawi', 'MW'),
+    ('my', 'MY'),
+    ('mys', 'MY'),
+    ('malaysia', 'MY'),
+    ('mv', 'MV'),
+    ('mdv', 'MV'),
+    ('maldives', 'MV'),
+    ('republic of maldives', 'MV'),
+    ('ml', 'ML'),
+    ('mli', 'ML'),
+    ('mali', 'ML'),
+    ('republic of mali', 'ML'),
+    ('mt', 'MT'),
+    ('mlt', 'MT'),
+    ('malta', 'MT'),
+    ('republic of malta', 'MT'),
+    ('mh', 'MH'),
+    ('mhl', 'MH'),
+    ('marshall islands', 'MH'),
+    ('republic of the marshall islands', 'MH'),
+    ('mq', 'MQ'),
+    ('mtq', 'MQ'),
+    ('martinique', 'MQ'),
+    ('mr', 'MR'),
+    ('mrt', 'MR'),
+    ('mauritania', 'MR'),
+    ('islamic republic of mauritania', 'MR'),
+    ('mu', 'MU'),
+    ('mus', 'MU'),
+    ('mauritius', 'MU'),
+    ('republic of mauritius', 'MU'),
+    ('yt', 'YT'),
+    ('myt', 'YT'),
+    ('mayotte', 'YT'),
+    ('mx', 'MX'),
+    ('mex', 'MX'),
+    ('mexico', 'MX'),
+    ('united mexican states', 'MX'),
+    ('fm', 'FM'),
+    ('fsm', 'FM'),
+    ('micronesia, federated states of', 'FM'),
+    ('federated states of micronesia', 'FM'),
+    ('md', 'MD'),
+    ('mda', 'MD'),
+    ('moldova', 'MD'),
+    ('moldova, republic of', 'MD'),
+    ('republic of moldova', 'MD'),
+    ('mc', 'MC'),
+    ('mco', 'MC'),
+    ('monaco', 'MC'),
+    ('principality of monaco', 'MC'),
+    ('mn', 'MN'),
+    ('mng', 'MN'),
+    ('mongolia', 'MN'),
+    ('me', 'ME'),
+    ('mne', 'ME'),
+    ('montenegro', 'ME'),
+    ('ms', 'MS'),
+    ('msr', 'MS'),
+    ('montserrat', 'MS'),
+    ('ma', 'MA'),
+    ('mar', 'MA'),
+    ('morocco', 'MA'),
+    ('kingdom of morocco', 'MA'),
+    ('mz', 'MZ'),
+    ('moz', 'MZ'),
+    ('mozambique', 'MZ'),
+    ('republic of mozambique', 'MZ'),
+    ('mm', 'MM'),
+    ('mmr', 'MM'),
+    ('myanmar', 'MM'),
+    ('republic of myanmar', 'MM'),
+    ('burma', 'MM'),
+    ('na', 'NA'),
+    ('nam', 'NA'),
+    ('namibia', 'NA'),
+    ('republic of namibia', 'NA'),
+    ('nr', 'NR'),
+    ('nru', 'NR'),
+    ('nauru', 'NR'),
+    ('republic of nauru', 'NR'),
+    ('np', 'NP'),
+    ('npl', 'NP'),
+    ('nepal', 'NP'),
+    ('federal democratic republic of nepal', 'NP'),
+    ('nl', 'NL'),
+    ('nld', 'NL'),
+    ('netherlands', 'NL'),
+    ('kingdom of the netherlands', 'NL'),
+    ('holland', 'NL'),
+    ('nc', 'NC'),
+    ('ncl', 'NC'),
+    ('new caledonia', 'NC'),
+    ('nz', 'NZ'),
+    ('nzl', 'NZ'),
+    ('new zealand', 'NZ'),
+    ('ni', 'NI'),
+    ('nic', 'NI'),
+    ('nicaragua', 'NI'),
+    ('republic of nicaragua', 'NI'),
+    ('ne', 'NE'),
+    ('ner', 'NE'),
+    ('niger', 'NE'),
+    ('republic of the niger', 'NE'),
+    ('ng', 'NG'),
+    ('nga', 'NG'),
+    ('nigeria', 'NG'),
+    ('federal republic of nigeria', 'NG'),
+    ('nu', 'NU'),
+    ('niu', 'NU'),
+    ('niue', 'NU'),
+    ('nf', 'NF'),
+    ('nfk', 'NF'),
+    ('norfolk island', 'NF'),
+    ('kp', 'KP'),
+    ('prk', 'KP'),
+    ('north korea', 'KP'),
+    ('korea, democratic people''s republic of', 'KP'),
+    ('democratic people''s republic of korea', 'KP'),
+    ('mk', 'MK'),
+    ('mkd', 'MK'),
+    ('north macedonia', 'MK'),
+    ('republic of north macedonia', 'MK'),
+    ('macedonia', 'MK'),
+    ('mp', 'MP'),
+    ('mnp', 'MP'),
+    ('northern mariana islands', 'MP'),
+    ('commonwealth of the northern mariana islands', 'MP'),
+    ('no', 'NO'),
+    ('nor', 'NO'),
+    ('norway', 'NO'),
+    ('kingdom of norway', 'NO'),
+    ('om', 'OM'),
+    ('omn', 'OM'),
+    ('oman', 'OM'),
+    ('sultanate of oman', 'OM'),
+    ('pk', 'PK'),
+    ('pak', 'PK'),
+    ('pakistan', 'PK'),
+    ('islamic republic of pakistan', 'PK'),
+    ('pw', 'PW'),
+    ('plw', 'PW'),
+    ('palau', 'PW'),
+    ('republic of palau', 'PW'),
+    ('ps', 'PS'),
+    ('pse', 'PS'),
+    ('palestine, state of', 'PS'),
+    ('the state of palestine', 'PS'),
+    ('palestine', 'PS'),
+    ('pa', 'PA'),
+    ('pan', 'PA'),
+    ('panama', 'PA'),
+    ('republic of panama', 'PA'),
+    ('pg', 'PG'),
+    ('png', 'PG'),
+    ('papua new guinea', 'PG'),
+    ('independent state of papua new guinea', 'PG'),
+    ('py', 'PY'),
+    ('pry', 'PY'),
+    ('paraguay', 'PY'),
+    ('republic of paraguay', 'PY'),
+    ('pe', 'PE'),
+    ('per', 'PE'),
+    ('peru', 'PE'),
+    ('republic of peru', 'PE'),
+    ('ph', 'PH'),
+    ('phl', 'PH'),
+    ('philippines', 'PH'),
+    ('republic of the philippines', 'PH'),
+    ('pn', 'PN'),
+    ('pcn', 'PN'),
+    ('pitcairn', 'PN'),
+    ('pl', 'PL'),
+    ('pol', 'PL'),
+    ('poland', 'PL'),
+    ('republic of poland', 'PL'),
+    ('pt', 'PT'),
+    ('prt', 'PT'),
+    ('portugal', 'PT'),
+    ('portuguese republic', 'PT'),
+    ('pr', 'PR'),
+    ('pri', 'PR'),
+    ('puerto rico', 'PR'),
+    ('qa', 'QA'),
+    ('qat', 'QA'),
+    ('qatar', 'QA'),
+    ('state of qatar', 'QA'),
+    ('ro', 'RO'),
+    ('rou', 'RO'),
+    ('romania', 'RO'),
+    ('ru', 'RU'),
+    ('rus', 'RU'),
+    ('russian federation', 'RU'),
+    ('russia', 'RU'),
+    ('rw', 'RW'),
+    ('rwa', 'RW'),
+    ('rwanda', 'RW'),
+    ('rwandese republic', 'RW'),
+    ('re', 'RE'),
+    ('reu', 'RE'),
+    ('réunion', 'RE'),
+    ('bl', 'BL'),
+    ('blm', 'BL'),
+    ('saint barthélemy', 'BL'),
+    ('sh', 'SH'),
+    ('shn', 'SH'),
+    ('saint helena, ascension and tristan da cunha', 'SH'),
+    ('kn', 'KN'),
+    ('kna', 'KN'),
+    ('saint kitts and nevis', 'KN'),
+    ('lc', 'LC'),
+    ('lca', 'LC'),
+    ('saint lucia', 'LC'),
+    ('mf', 'MF'),
+    ('maf', 'MF'),
+    ('saint martin (french part)', 'MF'),
+    ('pm', 'PM'),
+    ('spm', 'PM'),
+    ('saint pierre and miquelon', 'PM'),
+    ('vc', 'VC'),
+    ('vct', 'VC'),
+    ('saint vincent and the grenadines', 'VC'),
+    ('ws', 'WS'),
+    ('wsm', 'WS'),
+    ('samoa', 'WS'),
+    ('independent state of samoa', 'WS'),
+    ('sm', 'SM'),
+    ('smr', 'SM'),
+    ('san marino', 'SM'),
+    ('republic of san marino', 'SM'),
+    ('st', 'ST'),
+    ('stp', 'ST'),
+    ('sao tome and principe', 'ST'),
+    ('democratic republic of sao tome and principe', 'ST'),
+    ('sa', 'SA'),
+    ('sau', 'SA'),
+    ('saudi arabia', 'SA'),
+    ('kingdom of saudi arabia', 'SA'),
+    ('sn', 'SN'),
+    ('sen', 'SN'),
+    ('senegal', 'SN'),
+    ('republic of senegal', 'SN'),
+    ('rs', 'RS'),
+    ('srb', 'RS'),
+    ('serbia', 'RS'),
+    ('republic of serbia', 'RS'),
+    ('sc', 'SC'),
+    ('syc', 'SC'),
+    ('seychelles', 'SC'),
+    ('republic of seychelles', 'SC'),
+    ('sl', 'SL'),
+    ('sle', 'SL'),
+    ('sierra leone', 'SL'),
+    ('republic of sierra leone', 'SL'),
+    ('sg', 'SG'),
+    ('sgp', 'SG'),
+    ('singapore', 'SG'),
+    ('republic of singapore', 'SG'),
+    ('sx', 'SX'),
+    ('sxm', 'SX'),
+    ('sint maarten (dutch part)', 'SX'),
+    ('sk', 'SK'),
+    ('svk', 'SK'),
+    ('slovakia', 'SK'),
+    ('slovak republic', 'SK'),
+    ('si', 'SI'),
+    ('svn', 'SI'),
+    ('slovenia', 'SI'),
+    ('republic of slovenia', 'SI'),
+    ('sb', 'SB'),
+    ('slb', 'SB'),
+    ('solomon islands', 'SB'),
+    ('so', 'SO'),
+    ('som', 'SO'),
+    ('somalia', 'SO'),
+    ('federal republic of somalia', 'SO'),
+    ('za', 'ZA'),
+    ('zaf', 'ZA'),
+    ('south africa', 'ZA'),
+    ('republic of south africa', 'ZA'),
+    ('gs', 'GS'),
+    ('sgs', 'GS'),
+    ('south georgia and the south sandwich islands', 'GS'),
+    ('kr', 'KR'),
+    ('kor', 'KR'),
+    ('south korea', 'KR'),
+    ('korea, republic of', 'KR'),
+    ('ss', 'SS'),
+    ('ssd', 'SS'),
+    ('south sudan', 'SS'),
+    ('republic of south sudan', 'SS'),
+    ('es', 'ES'),
+    ('esp', 'ES'),
+    ('spain', 'ES'),
+    ('kingdom of spain', 'ES'),
+    ('lk', 'LK'),
+    ('lka', 'LK'),
+    ('sri lanka', 'LK'),
+    ('democratic socialist republic of sri lanka', 'LK'),
+    ('sd', 'SD'),
+    ('sdn', 'SD'),
+    ('sudan', 'SD'),
+    ('republic of the sudan', 'SD'),
+    ('sr', 'SR'),
+    ('sur', 'SR'),
+    ('suriname', 'SR'),
+    ('republic of suriname', 'SR'),
+    ('sj', 'SJ'),
+    ('sjm', 'SJ'),
+    ('svalbard and jan mayen', 'SJ'),
+    ('se', 'SE'),
+    ('swe', 'SE'),
+    ('sweden', 'SE'),
+    ('kingdom of sweden', 'SE'),
+    ('ch', 'CH'),
+    ('che', 'CH'),
+    ('switzerland', 'CH'),
+    ('swiss confederation', 'CH'),
+    ('sy', 'SY'),
+    ('syr', 'SY'),
+    ('syria', 'SY'),
+    ('syrian arab republic', 'SY'),
+    ('tw', 'TW'),
+    ('twn', 'TW'),
+    ('taiwan', 'TW'),
+    ('taiwan, province of china', 'TW'),
+    ('tj', 'TJ'),
+    ('tjk', 'TJ'),
+    ('tajikistan', 'TJ'),
+    ('republic of tajikistan', 'TJ'),
+    ('tz', 'TZ'),
+    ('tza', 'TZ'),
+    ('tanzania', 'TZ'),
+    ('tanzania, united republic of', 'TZ'),
+    ('united republic of tanzania', 'TZ'),
+    ('th', 'TH'),
+    ('tha', 'TH'),
+    ('thailand', 'TH'),
+    ('kingdom of thailand', 'TH'),
+    ('tl', 'TL'),
+    ('tls', 'TL'),
+    ('timor-leste', 'TL'),
+    ('democratic republic of timor-leste', 'TL'),
+    ('east timor', 'TL'),
+    ('tg', 'TG'),
+    ('tgo', 'TG'),
+    ('togo', 'TG'),
+    ('togolese republic', 'TG'),
+    ('tk', 'TK'),
+    ('tkl', 'TK'),
+    ('tokelau', 'TK'),
+    ('to', 'TO'),
+    ('ton', 'TO'),
+    ('tonga', 'TO'),
+    ('kingdom of tonga', 'TO'),
+    ('tt', 'TT'),
+    ('tto', 'TT'),
+    ('trinidad and tobago', 'TT'),
+    ('republic of trinidad and tobago', 'TT'),
+    ('tn', 'TN'),
+    ('tun', 'TN'),
+    ('tunisia', 'TN'),
+    ('republic of tunisia', 'TN'),
+    ('tm', 'TM'),
+    ('tkm', 'TM'),
+    ('turkmenistan', 'TM'),
+    ('tc', 'TC'),
+    ('tca', 'TC'),
+    ('turks and caicos islands', 'TC'),
+    ('tv', 'TV'),
+    ('tuv', 'TV'),
+    ('tuvalu', 'TV'),
+    ('tr', 'TR'),
+    ('tur', 'TR'),
+    ('türkiye', 'TR'),
+    ('republic of türkiye', 'TR'),
+    ('turkey', 'TR'),
+    ('ug', 'UG'),
+    ('uga', 'UG'),
+    ('uganda', 'UG'),
+    ('republic of uganda', 'UG'),
+    ('ua', 'UA'),
+    ('ukr', 'UA'),
+    ('ukraine', 'UA'),
+    ('ae', 'AE'),
+    ('are', 'AE'),
+    ('united arab emirates', 'AE'),
+    ('uae', 'AE'),
+    ('gb', 'GB'),
+    ('gbr', 'GB'),
+    ('united kingdom', 'GB'),
+    ('united kingdom of great britain and northern ireland', 'GB'),
+    ('uk', 'GB'),
+    ('great britain', 'GB'),
+    ('britain', 'GB'),
+    ('england', 'GB'),
+    ('scotland', 'GB'),
+    ('wales', 'GB'),
+    ('northern ireland', 'GB'),
+    ('us', 'US'),
+    ('usa', 'US'),
+    ('united states', 'US'),
+    ('united states of america', 'US'),
+    ('america', 'US'),
+    ('um', 'UM'),
+    ('umi', 'UM'),
+    ('united states minor outlying islands', 'UM'),
+    ('uy', 'UY'),
+    ('ury', 'UY'),
+    ('uruguay', 'UY'),
+    ('eastern republic of uruguay', 'UY'),
+    ('uz', 'UZ'),
+    ('uzb', 'UZ'),
+    ('uzbekistan', 'UZ'),
+    ('republic of uzbekistan', 'UZ'),
+    ('vu', 'VU'),
+    ('vut', 'VU'),
+    ('vanuatu', 'VU'),
+    ('republic of vanuatu', 'VU'),
+    ('ve', 'VE'),
+    ('ven', 'VE'),
+    ('venezuela', 'VE'),
+    ('venezuela, bolivarian republic of', 'VE'),
+    ('bolivarian republic of venezuela', 'VE'),
+    ('vn', 'VN'),
+    ('vnm', 'VN'),
+    ('vietnam', 'VN'),
+    ('viet nam', 'VN'),
+    ('socialist republic of viet nam', 'VN'),
+    ('vg', 'VG'),
+    ('vgb', 'VG'),
+    ('virgin islands, british', 'VG'),
+    ('british virgin islands', 'VG'),
+    ('vi', 'VI'),
+    ('vir', 'VI'),
+    ('virgin islands, u.s.', 'VI'),
+    ('virgin islands of the united states', 'VI'),
+    ('wf', 'WF'),
+    ('wlf', 'WF'),
+    ('wallis and futuna', 'WF'),
+    ('eh', 'EH'),
+    ('esh', 'EH'),
+    ('western sahara', 'EH'),
+    ('ye', 'YE'),
+    ('yem', 'YE'),
+    ('yemen', 'YE'),
+    ('republic of yemen', 'YE'),
+    ('zm', 'ZM'),
+    ('zmb', 'ZM'),
+    ('zambia', 'ZM'),
+    ('republic of zambia', 'ZM'),
+    ('zw', 'ZW'),
+    ('zwe', 'ZW'),
+    ('zimbabwe', 'ZW'),
+    ('republic of zimbabwe', 'ZW'),
+    ('ax', 'AX'),
+    ('ala', 'AX'),
+    ('åland islands', 'AX')
+) AS c (name, alpha2)
+WHERE LOWER(REGEXP_REPLACE(TRIM(t.country), '\s+', ' ', 'g')) = c.name;
@@ -0,0 +1,3 @@
+ALTER TABLE targets DROP COLUMN version;
+ALTER TABLE missions DROP COLUMN version;
+ALTER TABLE cats DROP COLUMN version;
@@ -0,0 +1,4 @@
+-- Versions are bumped on every change and exposed as ETags for optimistic concurrency
+ALTER TABLE cats ADD COLUMN version INT NOT NULL DEFAULT 1;
+ALTER TABLE missions ADD COLUMN version INT NOT NULL DEFAULT 1;
+ALTER TABLE targets ADD COLUMN version INT NOT NULL DEFAULT 1;
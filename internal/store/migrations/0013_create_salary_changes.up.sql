@@ -0,0 +1,17 @@
+-- cat_id has no foreign key so the history outlives deleted cats
+CREATE TABLE salary_changes (
+    id SERIAL PRIMARY KEY,
+    cat_id INT NOT NULL,
+    salary DECIMAL(10, 2) NOT NULL,
+    effective_from DATE NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    approved_by TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX salary_changes_cat_id_idx ON salary_changes (cat_id, effective_from);
+
+-- Earlier salaries are unknown, so the current ones are paid from the start of this month
+INSERT INTO salary_changes (cat_id, salary, effective_from, reason, approved_by)
+SELECT id, salary, date_trunc('month', CURRENT_DATE)::date, 'initial salary', 'system'
+FROM cats;
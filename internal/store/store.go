@@ -1,39 +1,148 @@
 package store
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
+	"errors"
 	"fmt"
+	"io"
+	"log"
 	"main/internal/config"
+	"main/internal/model"
+	"net"
+	"strings"
+	"time"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 type Store struct {
 	DB *sql.DB
+	// QueryTimeout and TxTimeout bound the reads and transactions of the repositories
+	QueryTimeout time.Duration
+	TxTimeout    time.Duration
 }
 
 func NewStore(cfg config.Config) (*Store, error) {
 	var err error
-	store := Store{}
-	store.DB, err = initPostgres(cfg)
+	store := Store{QueryTimeout: cfg.Postgres.QueryTimeout, TxTimeout: cfg.Postgres.TxTimeout}
+	store.DB, err = initPostgres(cfg.Postgres)
 	if err != nil {
 		return nil, err
 	}
+
+	if cfg.Postgres.AutoMigrate {
+		migrator, err := NewMigrator(store.DB)
+		if err != nil {
+			store.DB.Close()
+			return nil, err
+		}
+		if err := migrator.Up(context.Background()); err != nil {
+			store.DB.Close()
+			return nil, err
+		}
+	}
 	return &store, err
 }
 
-func initPostgres(cfg config.Config) (*sql.DB, error) {
-	connectionString := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
-		cfg.Postgres.Host, cfg.Postgres.Port, cfg.Postgres.User, cfg.Postgres.Password, cfg.Postgres.Dbname)
-	db, err := sql.Open("postgres", connectionString)
+// PoolStats reports the state of the connection pool
+func (s Store) PoolStats() model.PoolStats {
+	stats := s.DB.Stats()
+	return model.PoolStats{
+		MaxOpenConnections: stats.MaxOpenConnections,
+		OpenConnections:    stats.OpenConnections,
+		InUse:              stats.InUse,
+		Idle:               stats.Idle,
+		WaitCount:          stats.WaitCount,
+		WaitDurationMs:     stats.WaitDuration.Milliseconds(),
+		MaxIdleClosed:      stats.MaxIdleClosed,
+		MaxIdleTimeClosed:  stats.MaxIdleTimeClosed,
+		MaxLifetimeClosed:  stats.MaxLifetimeClosed,
+	}
+}
 
+// initPostgres opens the connection pool and waits until Postgres accepts
+// connections, retrying with exponential backoff
+func initPostgres(cfg config.Postgres) (*sql.DB, error) {
+	db, err := sql.Open("postgres", connectionString(cfg))
 	if err != nil {
 		return nil, err
 	}
+	db.SetMaxOpenConns(cfg.Pool.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.Pool.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.Pool.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.Pool.ConnMaxIdleTime)
 
-	if err = db.Ping(); err != nil {
-		return nil, err
+	backoff := cfg.Pool.ConnectBackoff
+	for attempt := 1; ; attempt++ {
+		if err = db.Ping(); err == nil {
+			break
+		}
+		if !isConnectionError(err) {
+			db.Close()
+			return nil, fmt.Errorf("unable to connect to postgres: %v", err)
+		}
+		if attempt == cfg.Pool.ConnectAttempts {
+			db.Close()
+			return nil, fmt.Errorf("unable to connect to postgres after %d attempts: %v", attempt, err)
+		}
+		log.Printf("Postgres is not available (attempt %d of %d), retrying in %s: %v", attempt, cfg.Pool.ConnectAttempts, backoff, err)
+		time.Sleep(backoff)
+		backoff = min(2*backoff, cfg.Pool.ConnectMaxBackoff)
 	}
 	fmt.Println("The database is connected")
-	return db, err
+	return db, nil
+}
+
+// isConnectionError reports whether err means Postgres could not be reached
+// or is not ready yet, which is worth retrying. Errors such as a wrong password,
+// a missing database or a TLS misconfiguration fail the same way every time.
+func isConnectionError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, driver.ErrBadConn) {
+		return true
+	}
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		// cannot_connect_now is reported while the server starts up or shuts down
+		return pqErr.Code == "57P03" || pqErr.Code.Class() == "08"
+	}
+	return false
+}
+
+// connectionString returns the configured DSN or builds a lib/pq key/value connection string
+func connectionString(cfg config.Postgres) string {
+	if cfg.DSN != "" {
+		return cfg.DSN
+	}
+
+	params := []string{
+		"host=" + quoteParam(cfg.Host),
+		fmt.Sprintf("port=%d", cfg.Port),
+		"user=" + quoteParam(cfg.User),
+		"password=" + quoteParam(cfg.Password),
+		"dbname=" + quoteParam(cfg.Dbname),
+		"sslmode=" + quoteParam(cfg.SSLMode),
+	}
+	if cfg.SSLRootCert != "" {
+		params = append(params, "sslrootcert="+quoteParam(cfg.SSLRootCert))
+	}
+	if cfg.StatementTimeout > 0 {
+		// Unknown keys are sent to the server as run-time parameters
+		params = append(params, fmt.Sprintf("statement_timeout=%d", cfg.StatementTimeout.Milliseconds()))
+	}
+	return strings.Join(params, " ")
+}
+
+// quoteParam quotes a connection string value so that it may contain spaces and quotes
+func quoteParam(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `'`, `\'`)
+	return "'" + value + "'"
+}
+
+// Close closes the connection pool, waiting for the started queries to finish
+func (s Store) Close() error {
+	return s.DB.Close()
 }